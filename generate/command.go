@@ -0,0 +1,116 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+	"github.com/mrparkers/terraform-provider-keycloak/provider"
+)
+
+const usage = `Usage: terraform-provider-keycloak generate -realm <realm> [-output <directory>]
+
+Writes Terraform configuration with import blocks for the resources of an existing realm. Run
+"terraform plan -generate-config-out=generated.tf" in the output directory afterwards to generate
+the resource blocks for them.
+
+The provider is configured with the same KEYCLOAK_* environment variables it supports within
+Terraform, such as KEYCLOAK_URL, KEYCLOAK_CLIENT_ID and KEYCLOAK_CLIENT_SECRET.
+
+Options:
+`
+
+// Run runs the generate command with the given arguments, and returns its exit code
+func Run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	realmId := flags.String("realm", "", "The realm to generate configuration for")
+	output := flags.String("output", ".", "The directory to write the configuration to")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *realmId == "" {
+		flags.Usage()
+		return 2
+	}
+
+	if err := run(context.Background(), *realmId, *output); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func run(ctx context.Context, realmId, output string) error {
+	keycloakClient, err := configureClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := Generate(ctx, keycloakClient, realmId)
+	if err != nil {
+		return err
+	}
+
+	return writeFiles(output, files)
+}
+
+// configureClient configures the provider without any arguments, so every setting comes from its environment variable
+func configureClient(ctx context.Context) (*keycloak.KeycloakClient, error) {
+	keycloakProvider := provider.KeycloakProvider()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{})
+
+	diags := keycloakProvider.Validate(config)
+	diags = append(diags, keycloakProvider.Configure(ctx, config)...)
+
+	if diags.HasError() {
+		var errs []error
+		for _, d := range diags {
+			errs = append(errs, fmt.Errorf("%s %s", d.Summary, d.Detail))
+		}
+
+		return nil, fmt.Errorf("failed to configure provider: %v", errors.Join(errs...))
+	}
+
+	return keycloakProvider.Meta().(*keycloak.KeycloakClient), nil
+}
+
+// writeFiles writes the generated configuration, refusing to overwrite any existing file
+func writeFiles(output string, files []*ConfigFile) error {
+	if err := os.MkdirAll(output, 0755); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		path := filepath.Join(output, file.Name)
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+
+		_, err = f.Write(file.Render())
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
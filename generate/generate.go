@@ -0,0 +1,467 @@
+// Package generate writes Terraform configuration that imports the resources of an existing realm. Together with
+// `terraform plan -generate-config-out`, this turns a realm that was configured by hand into Terraform configuration.
+package generate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+type importBlock struct {
+	resourceType string
+	name         string
+	id           string
+}
+
+// ConfigFile is a single .tf file of generated configuration
+type ConfigFile struct {
+	Name     string
+	blocks   []*importBlock
+	comments []string
+}
+
+type generator struct {
+	keycloakClient *keycloak.KeycloakClient
+	realmId        string
+	files          []*ConfigFile
+	names          map[string]bool
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Generate walks a realm and returns the configuration importing its clients, client scopes, protocol mappers, roles,
+// groups, authentication flows, identity providers and user federation
+func Generate(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId string) ([]*ConfigFile, error) {
+	g := &generator{
+		keycloakClient: keycloakClient,
+		realmId:        realmId,
+		names:          map[string]bool{},
+	}
+
+	steps := []func(context.Context) error{
+		g.realm,
+		g.clientScopes,
+		g.clients,
+		g.roles,
+		g.groups,
+		g.authenticationFlows,
+		g.identityProviders,
+		g.userFederation,
+	}
+
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.files, nil
+}
+
+func (g *generator) file(name string) *ConfigFile {
+	for _, file := range g.files {
+		if file.Name == name {
+			return file
+		}
+	}
+
+	file := &ConfigFile{Name: name}
+	g.files = append(g.files, file)
+
+	return file
+}
+
+// add records an import of a resource. The resource's name is derived from nameParts, and made unique if needed.
+func (g *generator) add(fileName, resourceType, id string, nameParts ...string) {
+	name := resourceName(nameParts...)
+
+	unique := name
+	for i := 2; g.names[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	g.names[resourceType+"."+unique] = true
+
+	file := g.file(fileName)
+	file.blocks = append(file.blocks, &importBlock{
+		resourceType: resourceType,
+		name:         unique,
+		id:           id,
+	})
+}
+
+// skip records a resource that can't be imported, so it shows up in the generated configuration as a comment
+func (g *generator) skip(fileName, reason string) {
+	file := g.file(fileName)
+	file.comments = append(file.comments, reason)
+}
+
+func (g *generator) realm(ctx context.Context) error {
+	realm, err := g.keycloakClient.GetRealm(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	g.add("realm.tf", "keycloak_realm", realm.Realm, realm.Realm)
+
+	return nil
+}
+
+func (g *generator) clientScopes(ctx context.Context) error {
+	clientScopes, err := g.keycloakClient.GetRealmClientScopes(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	for _, clientScope := range clientScopes {
+		switch clientScope.Protocol {
+		case "openid-connect":
+			g.add("client_scopes.tf", "keycloak_openid_client_scope", g.realmId+"/"+clientScope.Id, clientScope.Name)
+		case "saml":
+			g.add("client_scopes.tf", "keycloak_saml_client_scope", g.realmId+"/"+clientScope.Id, clientScope.Name)
+		default:
+			g.skip("client_scopes.tf", fmt.Sprintf("client scope %s uses the unsupported protocol %s", clientScope.Name, clientScope.Protocol))
+			continue
+		}
+
+		protocolMappers, err := g.keycloakClient.GetClientScopeGenericProtocolMappers(ctx, g.realmId, clientScope.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, protocolMapper := range protocolMappers {
+			g.add("client_scopes.tf", protocolMapperResourceType(protocolMapper.ProtocolMapper), fmt.Sprintf("%s/client-scope/%s/%s", g.realmId, clientScope.Id, protocolMapper.Id), clientScope.Name, protocolMapper.Name)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) clients(ctx context.Context) error {
+	clients, err := g.keycloakClient.GetGenericClients(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	for _, client := range clients {
+		switch client.Protocol {
+		case "openid-connect":
+			g.add("clients.tf", "keycloak_openid_client", g.realmId+"/"+client.Id, client.ClientId)
+		case "saml":
+			g.add("clients.tf", "keycloak_saml_client", g.realmId+"/"+client.Id, client.ClientId)
+		default:
+			g.skip("clients.tf", fmt.Sprintf("client %s uses the unsupported protocol %s", client.ClientId, client.Protocol))
+			continue
+		}
+
+		clientWithProtocolMappers, err := g.keycloakClient.GetGenericProtocolMappers(ctx, g.realmId, client.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, protocolMapper := range clientWithProtocolMappers.ProtocolMappers {
+			g.add("clients.tf", protocolMapperResourceType(protocolMapper.ProtocolMapper), fmt.Sprintf("%s/client/%s/%s", g.realmId, client.Id, protocolMapper.Id), client.ClientId, protocolMapper.Name)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) roles(ctx context.Context) error {
+	realmRoles, err := g.keycloakClient.GetRealmRoles(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	for _, role := range realmRoles {
+		g.add("roles.tf", "keycloak_role", g.realmId+"/"+role.Id, role.Name)
+	}
+
+	clients, err := g.keycloakClient.GetGenericClients(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	for _, client := range clients {
+		clientRoles, err := g.keycloakClient.GetClientRoles(ctx, g.realmId, []*keycloak.OpenidClient{{Id: client.Id}})
+		if err != nil {
+			return err
+		}
+
+		for _, role := range clientRoles {
+			g.add("roles.tf", "keycloak_role", g.realmId+"/"+role.Id, client.ClientId, role.Name)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) groups(ctx context.Context) error {
+	groups, err := g.keycloakClient.GetGroups(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	return g.addGroups(ctx, groups)
+}
+
+func (g *generator) addGroups(ctx context.Context, groups []*keycloak.Group) error {
+	for _, group := range groups {
+		g.add("groups.tf", "keycloak_group", g.realmId+"/"+group.Id, group.Path)
+
+		children, err := g.keycloakClient.GetGroupChildren(ctx, g.realmId, group.Id)
+		if err != nil {
+			return err
+		}
+
+		if err := g.addGroups(ctx, children); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) authenticationFlows(ctx context.Context) error {
+	flows, err := g.keycloakClient.ListAuthenticationFlows(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	for _, flow := range flows {
+		// built-in flows are created with every realm and can't be managed
+		if flow.BuiltIn || !flow.TopLevel {
+			continue
+		}
+
+		g.add("authentication.tf", "keycloak_authentication_flow", g.realmId+"/"+flow.Id, flow.Alias)
+
+		executions, err := g.keycloakClient.ListAuthenticationExecutions(ctx, g.realmId, flow.Alias)
+		if err != nil {
+			return err
+		}
+
+		// executions are listed depth-first, with the level of each one being its depth within the flow
+		parentFlowAliases := []string{flow.Alias}
+
+		for _, execution := range executions {
+			if execution.Level >= len(parentFlowAliases) {
+				return fmt.Errorf("unexpected level %d of execution %s in flow %s", execution.Level, execution.Id, flow.Alias)
+			}
+
+			parentFlowAlias := parentFlowAliases[execution.Level]
+
+			if execution.AuthenticationFlow {
+				g.add("authentication.tf", "keycloak_authentication_subflow", fmt.Sprintf("%s/%s/%s", g.realmId, parentFlowAlias, execution.FlowId), execution.DisplayName)
+				parentFlowAliases = append(parentFlowAliases[:execution.Level+1], execution.DisplayName)
+			} else {
+				g.add("authentication.tf", "keycloak_authentication_execution", fmt.Sprintf("%s/%s/%s", g.realmId, parentFlowAlias, execution.Id), parentFlowAlias, execution.ProviderId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) identityProviders(ctx context.Context) error {
+	identityProviders, err := g.keycloakClient.GetIdentityProviders(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	for _, identityProvider := range identityProviders {
+		resourceType, ok := identityProviderResourceTypes[identityProvider.ProviderId]
+		if !ok {
+			g.skip("identity_providers.tf", fmt.Sprintf("identity provider %s uses the unsupported provider %s", identityProvider.Alias, identityProvider.ProviderId))
+			continue
+		}
+
+		g.add("identity_providers.tf", resourceType, g.realmId+"/"+identityProvider.Alias, identityProvider.Alias)
+
+		mappers, err := g.keycloakClient.GetIdentityProviderMappers(ctx, g.realmId, identityProvider.Alias)
+		if err != nil {
+			return err
+		}
+
+		for _, mapper := range mappers {
+			g.add("identity_providers.tf", identityProviderMapperResourceType(mapper.IdentityProviderMapper), fmt.Sprintf("%s/%s/%s", g.realmId, identityProvider.Alias, mapper.Id), identityProvider.Alias, mapper.Name)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) userFederation(ctx context.Context) error {
+	realm, err := g.keycloakClient.GetRealm(ctx, g.realmId)
+	if err != nil {
+		return err
+	}
+
+	userFederations, err := g.keycloakClient.GetCustomUserFederations(ctx, g.realmId, realm.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, userFederation := range *userFederations {
+		if userFederation.ProviderId == "kerberos" {
+			g.add("user_federation.tf", "keycloak_kerberos_user_federation", g.realmId+"/"+userFederation.Id, userFederation.Name)
+			continue
+		}
+
+		if userFederation.ProviderId != "ldap" {
+			g.add("user_federation.tf", "keycloak_custom_user_federation", g.realmId+"/"+userFederation.Id, userFederation.Name)
+			continue
+		}
+
+		g.add("user_federation.tf", "keycloak_ldap_user_federation", g.realmId+"/"+userFederation.Id, userFederation.Name)
+
+		mappers, err := g.keycloakClient.GetLdapCustomMappers(ctx, g.realmId, userFederation.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, mapper := range mappers {
+			resourceType, ok := ldapMapperResourceType(mapper.ProviderId)
+			if !ok {
+				g.skip("user_federation.tf", fmt.Sprintf("LDAP mapper %s of %s has no provider", mapper.Name, userFederation.Name))
+				continue
+			}
+
+			g.add("user_federation.tf", resourceType, fmt.Sprintf("%s/%s/%s", g.realmId, userFederation.Id, mapper.Id), userFederation.Name, mapper.Name)
+		}
+	}
+
+	return nil
+}
+
+var identityProviderResourceTypes = map[string]string{
+	"oidc":          "keycloak_oidc_identity_provider",
+	"keycloak-oidc": "keycloak_oidc_identity_provider",
+	"google":        "keycloak_oidc_google_identity_provider",
+	"saml":          "keycloak_saml_identity_provider",
+}
+
+var protocolMapperResourceTypes = map[string]string{
+	"oidc-audience-mapper":              "keycloak_openid_audience_protocol_mapper",
+	"oidc-audience-resolve-mapper":      "keycloak_openid_audience_resolve_protocol_mapper",
+	"oidc-full-name-mapper":             "keycloak_openid_full_name_protocol_mapper",
+	"oidc-group-membership-mapper":      "keycloak_openid_group_membership_protocol_mapper",
+	"oidc-hardcoded-claim-mapper":       "keycloak_openid_hardcoded_claim_protocol_mapper",
+	"oidc-hardcoded-role-mapper":        "keycloak_openid_hardcoded_role_protocol_mapper",
+	"oidc-script-based-protocol-mapper": "keycloak_openid_script_protocol_mapper",
+	"oidc-usermodel-attribute-mapper":   "keycloak_openid_user_attribute_protocol_mapper",
+	"oidc-usermodel-client-role-mapper": "keycloak_openid_user_client_role_protocol_mapper",
+	"oidc-usermodel-property-mapper":    "keycloak_openid_user_property_protocol_mapper",
+	"oidc-usermodel-realm-role-mapper":  "keycloak_openid_user_realm_role_protocol_mapper",
+	"oidc-usersessionmodel-note-mapper": "keycloak_openid_user_session_note_protocol_mapper",
+	"saml-javascript-mapper":            "keycloak_saml_script_protocol_mapper",
+	"saml-user-attribute-mapper":        "keycloak_saml_user_attribute_protocol_mapper",
+	"saml-user-property-mapper":         "keycloak_saml_user_property_protocol_mapper",
+}
+
+func protocolMapperResourceType(protocolMapper string) string {
+	if resourceType, ok := protocolMapperResourceTypes[protocolMapper]; ok {
+		return resourceType
+	}
+
+	return "keycloak_generic_protocol_mapper"
+}
+
+// The types of identity provider mappers are prefixed with the provider they belong to, such as oidc-role-idp-mapper
+func identityProviderMapperResourceType(identityProviderMapper string) string {
+	switch {
+	case identityProviderMapper == "hardcoded-attribute-idp-mapper" || identityProviderMapper == "hardcoded-user-session-attribute-idp-mapper":
+		return "keycloak_hardcoded_attribute_identity_provider_mapper"
+	case strings.HasSuffix(identityProviderMapper, "hardcoded-role-idp-mapper"):
+		return "keycloak_hardcoded_role_identity_provider_mapper"
+	case strings.HasSuffix(identityProviderMapper, "-role-idp-mapper"):
+		return "keycloak_attribute_to_role_identity_provider_mapper"
+	case strings.HasSuffix(identityProviderMapper, "-user-attribute-idp-mapper") || strings.HasSuffix(identityProviderMapper, "-user-attribute-mapper"):
+		return "keycloak_attribute_importer_identity_provider_mapper"
+	case strings.HasSuffix(identityProviderMapper, "-username-idp-mapper"):
+		return "keycloak_user_template_importer_identity_provider_mapper"
+	}
+
+	return "keycloak_custom_identity_provider_mapper"
+}
+
+var ldapMapperResourceTypes = map[string]string{
+	"full-name-ldap-mapper":                "keycloak_ldap_full_name_mapper",
+	"group-ldap-mapper":                    "keycloak_ldap_group_mapper",
+	"hardcoded-ldap-attribute-mapper":      "keycloak_ldap_hardcoded_attribute_mapper",
+	"hardcoded-ldap-group-mapper":          "keycloak_ldap_hardcoded_group_mapper",
+	"hardcoded-ldap-role-mapper":           "keycloak_ldap_hardcoded_role_mapper",
+	"msad-lds-user-account-control-mapper": "keycloak_ldap_msad_lds_user_account_control_mapper",
+	"msad-user-account-control-mapper":     "keycloak_ldap_msad_user_account_control_mapper",
+	"role-ldap-mapper":                     "keycloak_ldap_role_mapper",
+	"user-attribute-ldap-mapper":           "keycloak_ldap_user_attribute_mapper",
+}
+
+// ldapMapperResourceType returns the resource managing an LDAP mapper. Mappers without a dedicated resource, such as
+// certificate or Kerberos principal mappers, are managed by keycloak_ldap_custom_mapper.
+func ldapMapperResourceType(providerId string) (string, bool) {
+	if providerId == "" {
+		return "", false
+	}
+
+	if resourceType, ok := ldapMapperResourceTypes[providerId]; ok {
+		return resourceType, true
+	}
+
+	return "keycloak_ldap_custom_mapper", true
+}
+
+// resourceName turns the names of Keycloak objects into a valid Terraform resource name
+func resourceName(parts ...string) string {
+	var sanitized []string
+	for _, part := range parts {
+		part = strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(part), "_"), "_")
+		if part != "" {
+			sanitized = append(sanitized, part)
+		}
+	}
+
+	name := strings.Join(sanitized, "_")
+	if name == "" || !(name[0] == '_' || (name[0] >= 'a' && name[0] <= 'z')) {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// hclString quotes a string for use in HCL, escaping the sequences that would start a template
+func hclString(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{")
+
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// Render returns the contents of the file
+func (file *ConfigFile) Render() []byte {
+	var builder strings.Builder
+
+	comments := append([]string{}, file.comments...)
+	sort.Strings(comments)
+
+	for _, comment := range comments {
+		fmt.Fprintf(&builder, "# Skipped %s\n", comment)
+	}
+
+	if len(comments) != 0 {
+		builder.WriteString("\n")
+	}
+
+	for i, block := range file.blocks {
+		if i != 0 {
+			builder.WriteString("\n")
+		}
+
+		fmt.Fprintf(&builder, "import {\n  to = %s.%s\n  id = %s\n}\n", block.resourceType, block.name, hclString(block.id))
+	}
+
+	return []byte(builder.String())
+}
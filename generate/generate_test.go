@@ -0,0 +1,187 @@
+package generate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+// A realm with one of everything, as returned by the Keycloak admin API
+var testRealmResponses = map[string]string{
+	"/admin/serverinfo":                `{"systemInfo": {"version": "24.0.1"}}`,
+	"/admin/realms/test":               `{"id": "realm-id", "realm": "test"}`,
+	"/admin/realms/test/client-scopes": `[{"id": "scope-id", "name": "profile", "protocol": "openid-connect"}]`,
+	"/admin/realms/test/client-scopes/scope-id/protocol-mappers/models": `[{"id": "scope-mapper-id", "name": "full name", "protocol": "openid-connect", "protocolMapper": "oidc-full-name-mapper"}]`,
+	"/admin/realms/test/clients":                                        `[{"id": "client-id", "clientId": "web-app", "protocol": "openid-connect"}, {"id": "saml-id", "clientId": "https://sp.example.com", "protocol": "saml"}]`,
+	"/admin/realms/test/clients/client-id":                              `{"id": "client-id", "clientId": "web-app", "protocolMappers": [{"id": "client-mapper-id", "name": "custom", "protocol": "openid-connect", "protocolMapper": "custom-mapper"}]}`,
+	"/admin/realms/test/clients/saml-id":                                `{"id": "saml-id", "clientId": "https://sp.example.com"}`,
+	"/admin/realms/test/clients/client-id/roles":                        `[{"id": "client-role-id", "name": "admin"}]`,
+	"/admin/realms/test/roles":                                          `[{"id": "realm-role-id", "name": "admin"}]`,
+	"/admin/realms/test/groups":                                         `[{"id": "parent-id", "name": "engineering", "path": "/engineering"}]`,
+	"/admin/realms/test/groups/parent-id/children":                      `[{"id": "child-id", "name": "admins", "path": "/engineering/admins"}]`,
+	"/admin/realms/test/authentication/flows":                           `[{"id": "browser-id", "alias": "browser", "topLevel": true, "builtIn": true}, {"id": "flow-id", "alias": "custom browser", "topLevel": true}]`,
+	"/admin/realms/test/authentication/flows/custom browser/executions": `[
+		{"id": "cookie-id", "providerId": "auth-cookie", "level": 0},
+		{"id": "forms-execution-id", "flowId": "forms-id", "authenticationFlow": true, "displayName": "forms", "level": 0},
+		{"id": "password-id", "providerId": "auth-username-password-form", "level": 1},
+		{"id": "idp-redirector-id", "providerId": "identity-provider-redirector", "level": 0}
+	]`,
+	"/admin/realms/test/identity-provider/instances":                                    `[{"alias": "corporate", "providerId": "oidc"}, {"alias": "github", "providerId": "github"}]`,
+	"/admin/realms/test/identity-provider/instances/corporate/mappers":                  `[{"id": "idp-mapper-id", "name": "department", "identityProviderMapper": "oidc-user-attribute-idp-mapper"}]`,
+	"/admin/realms/test/components?org.keycloak.storage.UserStorageProvider":            `[{"id": "ldap-id", "name": "directory", "providerId": "ldap", "parentId": "realm-id", "config": {}}, {"id": "kerberos-id", "name": "kerberos", "providerId": "kerberos", "parentId": "realm-id", "config": {}}, {"id": "custom-id", "name": "legacy", "providerId": "legacy-user-storage", "parentId": "realm-id", "config": {}}]`,
+	"/admin/realms/test/components?org.keycloak.storage.ldap.mappers.LDAPStorageMapper": `[{"id": "ldap-mapper-id", "name": "email", "providerId": "user-attribute-ldap-mapper", "parentId": "ldap-id", "config": {"ldap.attribute": ["mail"], "user.model.attribute": ["email"], "read.only": ["true"], "always.read.value.from.ldap": ["false"], "is.mandatory.in.ldap": ["false"]}}, {"id": "certificate-mapper-id", "name": "certificate", "providerId": "certificate-ldap-mapper", "parentId": "ldap-id", "config": {"ldap.attribute": ["userCertificate"], "is.derformatted": []}}, {"id": "broken-mapper-id", "name": "broken", "parentId": "ldap-id", "config": {}}]`,
+}
+
+func newTestRealmServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if componentType := r.URL.Query().Get("type"); componentType != "" {
+			path += "?" + componentType
+		}
+
+		response, ok := testRealmResponses[path]
+		if !ok {
+			response = "[]"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(response))
+	}))
+}
+
+func TestGenerate(t *testing.T) {
+	server := newTestRealmServer(t)
+	defer server.Close()
+
+	keycloakClient, err := keycloak.NewKeycloakClient(context.Background(), &keycloak.KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		ExternalToken: &keycloak.ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Generate(context.Background(), keycloakClient, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := t.TempDir()
+	if err := writeFiles(output, files); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string][]string{
+		"realm.tf": {
+			"to = keycloak_realm.test\n  id = \"test\"",
+		},
+		"client_scopes.tf": {
+			"to = keycloak_openid_client_scope.profile\n  id = \"test/scope-id\"",
+			"to = keycloak_openid_full_name_protocol_mapper.profile_full_name\n  id = \"test/client-scope/scope-id/scope-mapper-id\"",
+		},
+		"clients.tf": {
+			"to = keycloak_openid_client.web-app\n  id = \"test/client-id\"",
+			"to = keycloak_generic_protocol_mapper.web-app_custom\n  id = \"test/client/client-id/client-mapper-id\"",
+			"to = keycloak_saml_client.https_sp_example_com\n  id = \"test/saml-id\"",
+		},
+		"roles.tf": {
+			"to = keycloak_role.admin\n  id = \"test/realm-role-id\"",
+			"to = keycloak_role.web-app_admin\n  id = \"test/client-role-id\"",
+		},
+		"groups.tf": {
+			"to = keycloak_group.engineering\n  id = \"test/parent-id\"",
+			"to = keycloak_group.engineering_admins\n  id = \"test/child-id\"",
+		},
+		"authentication.tf": {
+			"to = keycloak_authentication_flow.custom_browser\n  id = \"test/flow-id\"",
+			"to = keycloak_authentication_execution.custom_browser_auth-cookie\n  id = \"test/custom browser/cookie-id\"",
+			"to = keycloak_authentication_subflow.forms\n  id = \"test/custom browser/forms-id\"",
+			"to = keycloak_authentication_execution.forms_auth-username-password-form\n  id = \"test/forms/password-id\"",
+			"to = keycloak_authentication_execution.custom_browser_identity-provider-redirector\n  id = \"test/custom browser/idp-redirector-id\"",
+		},
+		"identity_providers.tf": {
+			"# Skipped identity provider github uses the unsupported provider github",
+			"to = keycloak_oidc_identity_provider.corporate\n  id = \"test/corporate\"",
+			"to = keycloak_attribute_importer_identity_provider_mapper.corporate_department\n  id = \"test/corporate/idp-mapper-id\"",
+		},
+		"user_federation.tf": {
+			"to = keycloak_ldap_user_federation.directory\n  id = \"test/ldap-id\"",
+			"to = keycloak_kerberos_user_federation.kerberos\n  id = \"test/kerberos-id\"",
+			"to = keycloak_custom_user_federation.legacy\n  id = \"test/custom-id\"",
+			"to = keycloak_ldap_user_attribute_mapper.directory_email\n  id = \"test/ldap-id/ldap-mapper-id\"",
+			"to = keycloak_ldap_custom_mapper.directory_certificate\n  id = \"test/ldap-id/certificate-mapper-id\"",
+			"# Skipped LDAP mapper broken of directory has no provider",
+		},
+	}
+
+	for fileName, snippets := range expected {
+		contents, err := os.ReadFile(filepath.Join(output, fileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, snippet := range snippets {
+			if !strings.Contains(string(contents), snippet) {
+				t.Errorf("expected %s to contain %q, got:\n%s", fileName, snippet, contents)
+			}
+		}
+
+		if strings.Contains(string(contents), "browser-id") {
+			t.Errorf("expected built-in flows to be skipped, got:\n%s", contents)
+		}
+	}
+
+	// existing configuration is never overwritten
+	if err := writeFiles(output, files); err == nil {
+		t.Fatal("expected an error when the output files already exist")
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	testCases := map[string][]string{
+		"web-app":                {"Web-App"},
+		"https_sp_example_com":   {"https://sp.example.com"},
+		"engineering_admins":     {"/engineering/admins"},
+		"_123":                   {"123"},
+		"client_protocol_mapper": {"client", "", "Protocol Mapper"},
+		"_":                      {"!!!"},
+	}
+
+	for expected, parts := range testCases {
+		if name := resourceName(parts...); name != expected {
+			t.Errorf("expected name %s for %v, got %s", expected, parts, name)
+		}
+	}
+}
+
+func TestResourceName_unique(t *testing.T) {
+	g := &generator{names: map[string]bool{}}
+
+	g.add("roles.tf", "keycloak_role", "test/1", "Admin")
+	g.add("roles.tf", "keycloak_role", "test/2", "admin")
+	g.add("groups.tf", "keycloak_group", "test/3", "admin")
+
+	rendered := string(g.file("roles.tf").Render()) + string(g.file("groups.tf").Render())
+
+	for _, name := range []string{"keycloak_role.admin\n", "keycloak_role.admin_2\n", "keycloak_group.admin\n"} {
+		if !strings.Contains(rendered, name) {
+			t.Errorf("expected %q to be generated, got:\n%s", name, rendered)
+		}
+	}
+}
+
+func TestHclString(t *testing.T) {
+	value := hclString(`realm/${var.x}/%{if}/"quoted"\`)
+
+	if value != `"realm/$${var.x}/%%{if}/\"quoted\"\\"` {
+		t.Fatalf("unexpected escaped string %s", value)
+	}
+}
@@ -46,6 +46,7 @@ type AuthenticationExecutionInfo struct {
 	AuthenticationConfig string `json:"authenticationConfig"`
 	AuthenticationFlow   bool   `json:"authenticationFlow"`
 	Configurable         bool   `json:"configurable"`
+	DisplayName          string `json:"displayName,omitempty"`
 	FlowId               string `json:"flowId"`
 	Index                int    `json:"index"`
 	Level                int    `json:"level"`
@@ -0,0 +1,208 @@
+package keycloak
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	clientAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifetime = time.Minute
+)
+
+// ClientAssertion configures signed JWT (private_key_jwt) client authentication in place of a client secret
+type ClientAssertion struct {
+	// PEM encoded RSA or EC private key, or the path to a file containing it
+	PrivateKey string
+	// Optional JWS algorithm. Defaults to RS256 for RSA keys, and to the algorithm matching the curve for EC keys.
+	Algorithm string
+	// Optional key ID, sent as the `kid` header so Keycloak can pick the matching key from the client's JWKS
+	KeyId string
+}
+
+var clientAssertionHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"PS256": crypto.SHA256,
+	"PS384": crypto.SHA384,
+	"PS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+var ClientAssertionAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type clientAssertionSigner struct {
+	key       crypto.Signer
+	algorithm string
+	keyId     string
+}
+
+func newClientAssertionSigner(clientAssertion *ClientAssertion) (*clientAssertionSigner, error) {
+	keyPEM, err := readPEMOrFile(clientAssertion.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client assertion private key: %v", err)
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := clientAssertion.Algorithm
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == "" {
+			algorithm = "RS256"
+		}
+		if !strings.HasPrefix(algorithm, "RS") && !strings.HasPrefix(algorithm, "PS") {
+			return nil, fmt.Errorf("algorithm %s cannot be used with an RSA private key", algorithm)
+		}
+	case *ecdsa.PrivateKey:
+		curveAlgorithm := map[int]string{256: "ES256", 384: "ES384", 521: "ES512"}[k.Curve.Params().BitSize]
+		if curveAlgorithm == "" {
+			return nil, fmt.Errorf("unsupported elliptic curve %s", k.Curve.Params().Name)
+		}
+		if algorithm == "" {
+			algorithm = curveAlgorithm
+		}
+		if algorithm != curveAlgorithm {
+			return nil, fmt.Errorf("algorithm %s cannot be used with a %s private key", algorithm, k.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("client assertion private key must be an RSA or EC key")
+	}
+
+	if _, ok := clientAssertionHashes[algorithm]; !ok {
+		return nil, fmt.Errorf("unsupported client assertion algorithm %s", algorithm)
+	}
+
+	return &clientAssertionSigner{
+		key:       key,
+		algorithm: algorithm,
+		keyId:     clientAssertion.KeyId,
+	}, nil
+}
+
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("client assertion private key is not PEM encoded")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client assertion private key: %v", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("client assertion private key must be an RSA or EC key")
+	}
+
+	return signer, nil
+}
+
+// sign builds a JWT identifying the client, as described in RFC 7523 section 3
+func (signer *clientAssertionSigner) sign(clientId, audience string, now time.Time) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	header := map[string]string{
+		"alg": signer.algorithm,
+		"typ": "JWT",
+	}
+	if signer.keyId != "" {
+		header["kid"] = signer.keyId
+	}
+
+	claims := map[string]interface{}{
+		"iss": clientId,
+		"sub": clientId,
+		"aud": audience,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	}
+
+	encodedHeader, err := encodeJwtSegment(header)
+	if err != nil {
+		return "", err
+	}
+
+	encodedClaims, err := encodeJwtSegment(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+
+	hash := clientAssertionHashes[signer.algorithm]
+	hasher := hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	var signature []byte
+
+	switch key := signer.key.(type) {
+	case *rsa.PrivateKey:
+		if strings.HasPrefix(signer.algorithm, "PS") {
+			signature, err = rsa.SignPSS(rand.Reader, key, hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, key, hash, digest)
+		}
+	case *ecdsa.PrivateKey:
+		signature, err = signECDSA(key, digest)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// JWS expects ECDSA signatures as the fixed size concatenation of r and s, rather than ASN.1
+func signECDSA(key *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	size := (key.Curve.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	s.FillBytes(signature[size:])
+
+	return signature, nil
+}
+
+func encodeJwtSegment(segment interface{}) (string, error) {
+	encoded, err := json.Marshal(segment)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
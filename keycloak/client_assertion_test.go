@@ -0,0 +1,202 @@
+package keycloak
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newClientAssertionTokenEndpoint serves a token endpoint which only issues tokens for valid client assertions signed by the given key
+func newClientAssertionTokenEndpoint(t *testing.T, publicKey crypto.PublicKey) *httptest.Server {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realms/master/protocol/openid-connect/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %s", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.PostForm.Get("client_secret") != "" || r.PostForm.Get("client_assertion_type") != clientAssertionType {
+			t.Errorf("expected client assertion instead of client secret, got %v", r.PostForm)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := verifyTestJwt(r.PostForm.Get("client_assertion"), publicKey)
+		if err != nil {
+			t.Errorf("invalid client assertion: %s", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if claims["iss"] != "terraform" || claims["sub"] != "terraform" || claims["aud"] != server.URL+r.URL.Path || claims["jti"] == "" {
+			t.Errorf("unexpected client assertion claims: %v", claims)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+	}))
+
+	return server
+}
+
+func verifyTestJwt(jwt string, publicKey crypto.PublicKey) (map[string]interface{}, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidTestJwt
+	}
+
+	var header map[string]string
+	if err := decodeTestJwtSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	hash := clientAssertionHashes[header["alg"]]
+	hasher := hash.New()
+	hasher.Write([]byte(parts[0] + "." + parts[1]))
+	digest := hasher.Sum(nil)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(header["alg"], "PS") {
+			err = rsa.VerifyPSS(key, hash, digest, signature, nil)
+		} else {
+			err = rsa.VerifyPKCS1v15(key, hash, digest, signature)
+		}
+		if err != nil {
+			return nil, err
+		}
+	case *ecdsa.PublicKey:
+		size := len(signature) / 2
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return nil, errInvalidTestJwt
+		}
+	}
+
+	var claims map[string]interface{}
+	if err := decodeTestJwtSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+var errInvalidTestJwt = errors.New("invalid jwt")
+
+func decodeTestJwtSegment(segment string, v interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(decoded, v)
+}
+
+func testClientAssertionLogin(t *testing.T, privateKeyPEM string, publicKey crypto.PublicKey, algorithm string) {
+	server := newClientAssertionTokenEndpoint(t, publicKey)
+	defer server.Close()
+
+	clientAssertion := &ClientAssertion{
+		PrivateKey: privateKeyPEM,
+		Algorithm:  algorithm,
+		KeyId:      "terraform-key",
+	}
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:             server.URL,
+		ClientId:        "terraform",
+		Realm:           "master",
+		ClientTimeout:   5,
+		ClientAssertion: clientAssertion,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := keycloakClient.tokenManager.token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.accessToken != "token" {
+		t.Fatalf("expected access token to be issued, got %q", token.accessToken)
+	}
+}
+
+func TestClientAssertion_rsa(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	testClientAssertionLogin(t, keyPEM, &key.PublicKey, "")
+	testClientAssertionLogin(t, keyPEM, &key.PublicKey, "PS384")
+}
+
+func TestClientAssertion_ec(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+		testClientAssertionLogin(t, keyPEM, &key.PublicKey, "")
+	}
+}
+
+func TestClientAssertion_algorithmMustMatchKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+
+	for _, algorithm := range []string{"RS256", "ES384"} {
+		if _, err := newClientAssertionSigner(&ClientAssertion{PrivateKey: keyPEM, Algorithm: algorithm}); err == nil {
+			t.Fatalf("expected %s to be rejected for a P-256 key", algorithm)
+		}
+	}
+}
@@ -1,19 +1,123 @@
 package keycloak
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/hashicorp/errwrap"
 	"net/http"
+	"strings"
 )
 
 type ApiError struct {
 	Code    int
 	Message string
+
+	// The fields below are parsed from the JSON error representations returned by Keycloak, and are empty if the
+	// response body could not be parsed
+	ErrorCode        string
+	ErrorMessage     string
+	ErrorDescription string
+	FieldErrors      []ApiFieldError
+
+	responseBody []byte
+}
+
+// ApiFieldError is a validation error for a single field, such as the errors returned by the user profile when an
+// attribute is missing or invalid
+type ApiFieldError struct {
+	Field        string        `json:"field"`
+	ErrorMessage string        `json:"errorMessage"`
+	Params       []interface{} `json:"params,omitempty"`
 }
 
+type apiErrorResponse struct {
+	Error            string          `json:"error"`
+	ErrorMessage     string          `json:"errorMessage"`
+	ErrorDescription string          `json:"error_description"`
+	Field            string          `json:"field"`
+	Params           []interface{}   `json:"params"`
+	Errors           []ApiFieldError `json:"errors"`
+}
+
+// Error describes the request that failed, followed by the message Keycloak gave for it. The raw response body is only
+// included when it could not be parsed, such as HTML error pages returned by a proxy.
 func (e *ApiError) Error() string {
+	if detail := e.Detail(); detail != "" {
+		return fmt.Sprintf("%s %s", e.Message, detail)
+	}
+
+	if len(e.FieldErrors) != 0 {
+		fieldErrors := make([]string, len(e.FieldErrors))
+		for i, fieldError := range e.FieldErrors {
+			fieldErrors[i] = fmt.Sprintf("%s: %s", fieldError.Field, fieldError.String())
+		}
+
+		return fmt.Sprintf("%s %s", e.Message, strings.Join(fieldErrors, "; "))
+	}
+
+	if len(e.responseBody) != 0 {
+		return fmt.Sprintf("%s Response body: %s", e.Message, e.responseBody)
+	}
+
 	return e.Message
 }
 
+// Detail returns the most descriptive message Keycloak gave for the error, or an empty string if there is none
+func (e *ApiError) Detail() string {
+	if e.ErrorMessage != "" {
+		return e.ErrorMessage
+	}
+
+	if e.ErrorDescription != "" {
+		return e.ErrorDescription
+	}
+
+	return e.ErrorCode
+}
+
+func (e *ApiFieldError) String() string {
+	if len(e.Params) == 0 {
+		return e.ErrorMessage
+	}
+
+	params := make([]string, len(e.Params))
+	for i, param := range e.Params {
+		params[i] = fmt.Sprintf("%v", param)
+	}
+
+	return fmt.Sprintf("%s (%s)", e.ErrorMessage, strings.Join(params, ", "))
+}
+
+func newApiError(code int, message string, body []byte) *ApiError {
+	apiError := &ApiError{
+		Code:         code,
+		Message:      message,
+		responseBody: body,
+	}
+
+	var response apiErrorResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return apiError
+	}
+
+	apiError.ErrorCode = response.Error
+	apiError.ErrorMessage = response.ErrorMessage
+	apiError.ErrorDescription = response.ErrorDescription
+	apiError.FieldErrors = response.Errors
+
+	// a single validation error is returned without the surrounding list
+	if response.Field != "" {
+		apiError.FieldErrors = append(apiError.FieldErrors, ApiFieldError{
+			Field:        response.Field,
+			ErrorMessage: response.ErrorMessage,
+			Params:       response.Params,
+		})
+	}
+
+	return apiError
+}
+
 func ErrorIs404(err error) bool {
 	keycloakError, ok := errwrap.GetType(err, &ApiError{}).(*ApiError)
 
@@ -25,3 +129,16 @@ func ErrorIs409(err error) bool {
 
 	return ok && keycloakError != nil && keycloakError.Code == http.StatusConflict
 }
+
+// AsApiError returns the ApiError wrapped within err, if there is one. Both errors wrapped with errwrap and with
+// fmt.Errorf's %w verb are supported.
+func AsApiError(err error) (*ApiError, bool) {
+	var keycloakError *ApiError
+	if errors.As(err, &keycloakError) {
+		return keycloakError, true
+	}
+
+	keycloakError, ok := errwrap.GetType(err, &ApiError{}).(*ApiError)
+
+	return keycloakError, ok && keycloakError != nil
+}
@@ -0,0 +1,85 @@
+package keycloak
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestApiError_fieldErrors(t *testing.T) {
+	body := `{"errors": [{"field": "email", "errorMessage": "invalidEmailMessage", "params": ["email", "not-an-email"]}, {"field": "department", "errorMessage": "missingAttributeMessage", "params": ["department"]}]}`
+
+	apiError := newApiError(http.StatusBadRequest, "error", []byte(body))
+
+	if len(apiError.FieldErrors) != 2 {
+		t.Fatalf("expected two field errors, got %+v", apiError.FieldErrors)
+	}
+
+	if apiError.FieldErrors[0].Field != "email" || apiError.FieldErrors[0].String() != "invalidEmailMessage (email, not-an-email)" {
+		t.Errorf("unexpected field error: %+v", apiError.FieldErrors[0])
+	}
+
+	if apiError.FieldErrors[1].Field != "department" {
+		t.Errorf("unexpected field error: %+v", apiError.FieldErrors[1])
+	}
+}
+
+func TestApiError_singleFieldError(t *testing.T) {
+	body := `{"field": "username", "errorMessage": "usernameExistsMessage", "params": ["username"]}`
+
+	apiError := newApiError(http.StatusConflict, "error", []byte(body))
+
+	if len(apiError.FieldErrors) != 1 || apiError.FieldErrors[0].Field != "username" || apiError.Detail() != "usernameExistsMessage" {
+		t.Fatalf("unexpected field errors: %+v", apiError)
+	}
+}
+
+func TestApiError_messages(t *testing.T) {
+	testCases := map[string]string{
+		`{"errorMessage": "User exists with same username"}`:                          "User exists with same username",
+		`{"error": "invalid_grant", "error_description": "Invalid user credentials"}`: "Invalid user credentials",
+		`{"error": "unknown_error"}`:                                                  "unknown_error",
+		`<html>Bad Gateway</html>`:                                                    "",
+	}
+
+	for body, expected := range testCases {
+		apiError := newApiError(http.StatusBadRequest, "error", []byte(body))
+
+		if apiError.Detail() != expected {
+			t.Errorf("expected detail %q for body %s, got %q", expected, body, apiError.Detail())
+		}
+
+		if len(apiError.FieldErrors) != 0 {
+			t.Errorf("expected no field errors for body %s, got %+v", body, apiError.FieldErrors)
+		}
+	}
+}
+
+func TestApiError_error(t *testing.T) {
+	testCases := map[string]string{
+		`{"errorMessage": "User exists with same username"}`:                      "error sending POST request. User exists with same username",
+		`{"errors": [{"field": "email", "errorMessage": "invalidEmailMessage"}]}`: "error sending POST request. email: invalidEmailMessage",
+		`<html>Bad Gateway</html>`:                                                "error sending POST request. Response body: <html>Bad Gateway</html>",
+		``:                                                                        "error sending POST request.",
+	}
+
+	for body, expected := range testCases {
+		apiError := newApiError(http.StatusBadRequest, "error sending POST request.", []byte(body))
+
+		if apiError.Error() != expected {
+			t.Errorf("expected error %q for body %s, got %q", expected, body, apiError.Error())
+		}
+	}
+}
+
+func TestAsApiError(t *testing.T) {
+	err := fmt.Errorf("failed to create user: %w", newApiError(http.StatusBadRequest, "error", nil))
+
+	if apiError, ok := AsApiError(err); !ok || apiError.Code != http.StatusBadRequest {
+		t.Fatalf("expected wrapped api error to be found, got %v", apiError)
+	}
+
+	if _, ok := AsApiError(fmt.Errorf("some other error")); ok {
+		t.Fatal("expected no api error to be found")
+	}
+}
@@ -0,0 +1,119 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestExecCredentialHelperProcess is not a real test. It is run as a credential plugin by the tests below.
+func TestExecCredentialHelperProcess(t *testing.T) {
+	if os.Getenv("KEYCLOAK_TEST_EXEC_CREDENTIAL") != "1" {
+		return
+	}
+
+	if os.Getenv("KEYCLOAK_TEST_EXEC_FAIL") == "1" {
+		fmt.Fprint(os.Stderr, "broker unavailable")
+		os.Exit(1)
+	}
+
+	fmt.Printf(`{"access_token": %q, "expires_in": 60}`, os.Args[len(os.Args)-1])
+	os.Exit(0)
+}
+
+func testExecCredential(token string, env map[string]string) *ExecCredential {
+	env["KEYCLOAK_TEST_EXEC_CREDENTIAL"] = "1"
+
+	return &ExecCredential{
+		Command: os.Args[0],
+		Args:    []string{"-test.run=TestExecCredentialHelperProcess", "--", token},
+		Env:     env,
+	}
+}
+
+func newExternalTokenTestServer(t *testing.T, expectedToken string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/protocol/openid-connect/token") {
+			t.Errorf("unexpected login request to %s", r.URL.Path)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+expectedToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"systemInfo": {"version": "24.0.1"}}`))
+	}))
+}
+
+func TestExternalToken_accessToken(t *testing.T) {
+	server := newExternalTokenTestServer(t, "static-token")
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		InitialLogin:  true,
+		ClientTimeout: 5,
+		ExternalToken: &ExternalToken{AccessToken: "static-token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, _ := keycloakClient.VersionIsGreaterThanOrEqualTo(context.Background(), Version_24); !ok {
+		t.Fatal("expected server version to be read with the external access token")
+	}
+}
+
+func TestExternalToken_expiredAccessToken(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp": %d}`, time.Now().Add(-time.Minute).Unix())))
+	externalToken := &ExternalToken{AccessToken: "header." + claims + ".signature"}
+
+	if _, err := externalToken.login(context.Background()); err == nil {
+		t.Fatal("expected an error for an expired access token")
+	}
+}
+
+func TestExternalToken_exec(t *testing.T) {
+	server := newExternalTokenTestServer(t, "exec-token")
+	defer server.Close()
+
+	externalToken := &ExternalToken{Exec: testExecCredential("exec-token", map[string]string{})}
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		InitialLogin:  true,
+		ClientTimeout: 5,
+		ExternalToken: externalToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := keycloakClient.tokenManager.token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.tokenType != "Bearer" || token.renewAt.IsZero() {
+		t.Fatalf("expected a bearer token with a known lifetime, got %+v", token)
+	}
+}
+
+func TestExternalToken_execFailure(t *testing.T) {
+	externalToken := &ExternalToken{Exec: testExecCredential("exec-token", map[string]string{"KEYCLOAK_TEST_EXEC_FAIL": "1"})}
+
+	_, err := externalToken.login(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "broker unavailable") {
+		t.Fatalf("expected the command's error output to be reported, got %v", err)
+	}
+}
@@ -17,9 +17,7 @@ type GenericClient struct {
 }
 
 func (keycloakClient *KeycloakClient) listGenericClients(ctx context.Context, realmId string) ([]*GenericClient, error) {
-	var clients []*GenericClient
-
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients", realmId), &clients, nil)
+	clients, err := getPaginated[*GenericClient](ctx, keycloakClient, fmt.Sprintf("/realms/%s/clients", realmId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -31,6 +29,11 @@ func (keycloakClient *KeycloakClient) listGenericClients(ctx context.Context, re
 	return clients, nil
 }
 
+// GetGenericClients returns the clients of a realm, regardless of their protocol
+func (keycloakClient *KeycloakClient) GetGenericClients(ctx context.Context, realmId string) ([]*GenericClient, error) {
+	return keycloakClient.listGenericClients(ctx, realmId)
+}
+
 func (keycloakClient *KeycloakClient) GetGenericClient(ctx context.Context, realmId, id string) (*GenericClient, error) {
 	var client GenericClient
 
@@ -3,6 +3,7 @@ package keycloak
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
@@ -18,45 +19,131 @@ type Group struct {
 	Attributes  map[string][]string `json:"attributes"`
 }
 
-/*
- * There is no way to get a subgroup's parent ID using the Keycloak API (that I know of, PRs are welcome)
- * The best we can do is check subGroup's path with the group's path to figure out what sub-path to follow
- * until we find it.
- */
+// groupRepresentation is used when reading a single group, as Keycloak 23 and later include the ID of its parent
+type groupRepresentation struct {
+	*Group
+	ParentId string `json:"parentId,omitempty"`
+}
+
+// splitGroupPath splits a group path such as /parent/child into its segments. Keycloak escapes slashes within group
+// names as ~/, and these are kept as part of their segment.
+func splitGroupPath(path string) []string {
+	var segments []string
+
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return segments
+	}
+
+	start := 0
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && (i == 0 || path[i-1] != '~') {
+			segments = append(segments, path[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(segments, path[start:])
+}
+
+// groupParentId finds the ID of a group's parent. When the server did not return it, the parent is looked up by its
+// path, which is the group's path without its last segment.
 func (keycloakClient *KeycloakClient) groupParentId(ctx context.Context, group *Group) (string, error) {
-	// Check the path of the group being passed in.
+	if group.ParentId != "" {
+		return group.ParentId, nil
+	}
+
+	segments := splitGroupPath(group.Path)
+
 	// If there is only one group in the path, then this is a top-level group with no parentId
-	if group.Path == "/"+group.Name {
+	if len(segments) <= 1 {
 		return "", nil
 	}
 
-	groups, err := keycloakClient.ListGroupsWithName(ctx, group.RealmId, group.Name)
+	parent, err := keycloakClient.getGroupByPath(ctx, group.RealmId, "/"+strings.Join(segments[:len(segments)-1], "/"))
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("unable to determine parent ID for group with path %s: %v", group.Path, err)
 	}
 
-	var parentGroup Group
-	if parentGroupId, found := findParentGroup(*group, groups, parentGroup); found {
-		return parentGroupId, nil
+	return parent.Id, nil
+}
+
+func (keycloakClient *KeycloakClient) getGroupByPath(ctx context.Context, realmId, path string) (*Group, error) {
+	group := groupRepresentation{Group: &Group{}}
+
+	// escaped slashes must reach Keycloak as ~/, so only the names between them are escaped
+	segments := splitGroupPath(path)
+	for i, segment := range segments {
+		names := strings.Split(segment, "~/")
+		for j, name := range names {
+			names[j] = url.PathEscape(name)
+		}
+
+		segments[i] = strings.Join(names, "~/")
 	}
 
-	// maybe panic here?  this should never happen
-	return "", fmt.Errorf("unable to determine parent ID for group with path %s", group.Path)
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/group-by-path/%s", realmId, strings.Join(segments, "/")), &group, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Group.RealmId = realmId
+	group.Group.ParentId = group.ParentId
+
+	return group.Group, nil
+}
+
+// GetGroupByPath finds a group by its full path, such as /parent/child. Unlike names, paths are unique within a realm.
+func (keycloakClient *KeycloakClient) GetGroupByPath(ctx context.Context, realmId, path string) (*Group, error) {
+	group, err := keycloakClient.getGroupByPath(ctx, realmId, path)
+	if err != nil {
+		return nil, err
+	}
+
+	parentId, err := keycloakClient.groupParentId(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	group.ParentId = parentId
+
+	return group, nil
 }
 
-func findParentGroup(group Group, ingroups []*Group, parentGroup Group) (string, bool) {
-	for _, grp := range ingroups {
-		if grp.Id == group.Id {
-			return parentGroup.Id, true
+/*
+ * Keycloak 23 and later no longer return subgroups within their parent, and provide a paginated endpoint for them instead.
+ * Older versions only return subgroups inline.
+ */
+func (keycloakClient *KeycloakClient) GetGroupChildren(ctx context.Context, realmId, groupId string) ([]*Group, error) {
+	var children []*Group
+
+	versionOk, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, Version_23)
+	if err != nil {
+		return nil, err
+	}
+
+	if versionOk {
+		children, err = getPaginated[*Group](ctx, keycloakClient, fmt.Sprintf("/realms/%s/groups/%s/children", realmId, groupId), nil)
+		if err != nil {
+			return nil, err
 		}
-		if strings.HasPrefix(group.Path, grp.Path+"/") {
+	} else {
+		var group Group
 
-			if parentGroupId, found := findParentGroup(group, grp.SubGroups, *grp); found {
-				return parentGroupId, found
-			}
+		err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups/%s", realmId, groupId), &group, nil)
+		if err != nil {
+			return nil, err
 		}
+
+		children = group.SubGroups
+	}
+
+	for _, child := range children {
+		child.RealmId = realmId
+		child.ParentId = groupId
 	}
-	return "", false
+
+	return children, nil
 }
 
 func (keycloakClient *KeycloakClient) ValidateGroupMembers(usernames []interface{}) error {
@@ -93,9 +180,7 @@ func (keycloakClient *KeycloakClient) NewGroup(ctx context.Context, group *Group
 }
 
 func (keycloakClient *KeycloakClient) GetGroups(ctx context.Context, realmId string) ([]*Group, error) {
-	var groups []*Group
-
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups", realmId), &groups, nil)
+	groups, err := getPaginated[*Group](ctx, keycloakClient, fmt.Sprintf("/realms/%s/groups", realmId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -108,79 +193,64 @@ func (keycloakClient *KeycloakClient) GetGroups(ctx context.Context, realmId str
 }
 
 func (keycloakClient *KeycloakClient) GetGroup(ctx context.Context, realmId, id string) (*Group, error) {
-	var group Group
+	group := groupRepresentation{Group: &Group{}}
 
 	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups/%s", realmId, id), &group, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	group.RealmId = realmId // it's important to set RealmId here because fetching the ParentId depends on it
+	group.Group.RealmId = realmId // it's important to set RealmId here because fetching the ParentId depends on it
+	group.Group.ParentId = group.ParentId
 
-	parentId, err := keycloakClient.groupParentId(ctx, &group)
+	parentId, err := keycloakClient.groupParentId(ctx, group.Group)
 	if err != nil {
 		return nil, err
 	}
 
-	group.ParentId = parentId
+	group.Group.ParentId = parentId
 
-	return &group, nil
+	return group.Group, nil
 }
 
 func (keycloakClient *KeycloakClient) GetGroupByName(ctx context.Context, realmId, name string) (*Group, error) {
-	var groups []Group
-
 	// We can't get a group by name, so we have to search for it
-	params := map[string]string{
-		"search": name,
-	}
-
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups", realmId), &groups, params)
+	groups, err := keycloakClient.ListGroupsWithName(ctx, realmId, name)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(groups) == 0 {
+	// The search returns every group whose name contains the search string, along with the parents of matching subgroups
+	matches := findGroupsByName(name, groups)
+	if len(matches) == 0 {
 		return nil, fmt.Errorf("no group with name " + name + " found")
 	}
 
-	// The search may return more than 1 result even if there is a group exactly matching the search string
-	groupsPtr := make([]*Group, len(groups))
-	for i := range groups {
-		groupsPtr[i] = &groups[i]
-	}
-	group := getGroupByDFS(name, groupsPtr)
-	if group != nil {
-		group.RealmId = realmId // it's important to set RealmId here because fetching the ParentId depends on it
-
-		parentId, err := keycloakClient.groupParentId(ctx, group)
-		if err != nil {
-			return nil, err
+	if len(matches) > 1 {
+		paths := make([]string, len(matches))
+		for i, match := range matches {
+			paths[i] = match.Path
 		}
 
-		group.ParentId = parentId
-
-		return group, nil
+		return nil, fmt.Errorf("found %d groups with name %s, at paths %s. Look the group up by its path instead", len(matches), name, strings.Join(paths, ", "))
 	}
 
-	return nil, fmt.Errorf("no group with name " + name + " found")
+	return keycloakClient.GetGroupByPath(ctx, realmId, matches[0].Path)
 }
 
-/*
-Find group by name in groups returned by /groups?search=${group_name}
-If there are multiple groups match the name, it will return the first one it found, using DFS algorithm
-*/
-func getGroupByDFS(groupName string, groups []*Group) *Group {
+// findGroupsByName returns every group with exactly the given name, in depth-first order
+func findGroupsByName(groupName string, groups []*Group) []*Group {
+	var matches []*Group
+
 	for _, group := range groups {
 		if groupName == group.Name {
-			return group
-		}
-		groupFound := getGroupByDFS(groupName, group.SubGroups)
-		if groupFound != nil {
-			return groupFound
+			matches = append(matches, group)
 		}
+
+		matches = append(matches, findGroupsByName(groupName, group.SubGroups)...)
 	}
-	return nil
+
+	return matches
 }
 
 func (keycloakClient *KeycloakClient) UpdateGroup(ctx context.Context, group *Group) error {
@@ -192,33 +262,26 @@ func (keycloakClient *KeycloakClient) DeleteGroup(ctx context.Context, realmId,
 }
 
 func (keycloakClient *KeycloakClient) ListGroupsWithName(ctx context.Context, realmId, name string) ([]*Group, error) {
-	var groups []*Group
-
 	params := map[string]string{
 		"search": name,
 	}
 
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups", realmId), &groups, params)
+	groups, err := getPaginated[*Group](ctx, keycloakClient, fmt.Sprintf("/realms/%s/groups", realmId), params)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, group := range groups {
+		group.RealmId = realmId
+	}
+
 	return groups, nil
 }
 
 func (keycloakClient *KeycloakClient) GetGroupMembers(ctx context.Context, realmId, groupId string) ([]*User, error) {
-	var users []*User
-	var first, pagination int = 0, 50
-	var iterationUsers []*User
-
-	for ok := true; ok; ok = len(iterationUsers) > 0 {
-		iterationUsers = nil
-		err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups/%s/members?max=%d&first=%d", realmId, groupId, pagination, first), &iterationUsers, nil)
-		if err != nil {
-			return nil, err
-		}
-		users = append(users, iterationUsers...)
-		first += pagination
+	users, err := getPaginated[*User](ctx, keycloakClient, fmt.Sprintf("/realms/%s/groups/%s/members", realmId, groupId), nil)
+	if err != nil {
+		return nil, err
 	}
 
 	for _, user := range users {
@@ -0,0 +1,147 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitGroupPath(t *testing.T) {
+	testCases := map[string][]string{
+		"":                   nil,
+		"/":                  nil,
+		"/parent":            {"parent"},
+		"/parent/child":      {"parent", "child"},
+		"/a~/b/child":        {"a~/b", "child"},
+		"/parent/a~/b~/c":    {"parent", "a~/b~/c"},
+		"/parent/child/leaf": {"parent", "child", "leaf"},
+	}
+
+	for path, expected := range testCases {
+		if segments := splitGroupPath(path); !reflect.DeepEqual(segments, expected) {
+			t.Errorf("expected path %s to be split into %q, got %q", path, expected, segments)
+		}
+	}
+}
+
+func TestGetGroupByPath_escapedSlash(t *testing.T) {
+	groups := map[string]*Group{
+		"/admin/realms/master/group-by-path/a~/b":          {Id: "parent", Name: "a/b", Path: "/a~/b"},
+		"/admin/realms/master/group-by-path/a~/b/c~/d%20e": {Id: "child", Name: "c/d e", Path: "/a~/b/c~/d e"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		group, ok := groups[r.URL.EscapedPath()]
+		if !ok {
+			t.Errorf("unexpected request for %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(group)
+	}))
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := keycloakClient.GetGroupByPath(context.Background(), "master", "/a~/b/c~/d e")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if group.Id != "child" || group.ParentId != "parent" {
+		t.Fatalf("expected group child with parent parent, got %+v", group)
+	}
+}
+
+func TestGetGroup_parentIdFromRepresentation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/realms/master/groups/child" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"child","name":"child","path":"/a~/b/child","parentId":"parent"}`))
+	}))
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := keycloakClient.GetGroup(context.Background(), "master", "child")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if group.ParentId != "parent" || group.RealmId != "master" {
+		t.Fatalf("expected group with parent parent in realm master, got %+v", group)
+	}
+}
+
+func TestGetGroupByName_multipleMatches(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/admin/realms/master/groups" || r.URL.Query().Get("search") != "admins" || r.URL.Query().Get("max") == "" {
+			t.Errorf("unexpected request for %s", r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var groups []*Group
+		if r.URL.Query().Get("first") == "0" {
+			groups = []*Group{
+				{Id: "engineering", Name: "engineering", Path: "/engineering", SubGroups: []*Group{{Id: "engineering-admins", Name: "admins", Path: "/engineering/admins"}}},
+				{Id: "sales", Name: "sales", Path: "/sales", SubGroups: []*Group{{Id: "sales-admins", Name: "admins", Path: "/sales/admins"}}},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(groups)
+	}))
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		PageSize:      2,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = keycloakClient.GetGroupByName(context.Background(), "master", "admins")
+	if err == nil || !strings.Contains(err.Error(), "/engineering/admins, /sales/admins") || !strings.Contains(err.Error(), "path") {
+		t.Fatalf("expected an error listing the paths of both groups, got %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected the search to be paginated, got %d requests", requests)
+	}
+}
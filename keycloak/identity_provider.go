@@ -80,6 +80,20 @@ func (keycloakClient *KeycloakClient) NewIdentityProvider(ctx context.Context, i
 	return nil
 }
 
+// ValidateSocialIdentityProvider ensures the provider ID of a social identity provider is installed on the server
+func (keycloakClient *KeycloakClient) ValidateSocialIdentityProvider(ctx context.Context, identityProvider *IdentityProvider) error {
+	serverInfo, err := keycloakClient.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !serverInfo.providerInstalled("social", identityProvider.ProviderId) {
+		return fmt.Errorf("validation error: social identity provider \"%s\" does not exist on the server, installed providers: %s", identityProvider.ProviderId, serverInfo.getInstalledProvidersNames("social"))
+	}
+
+	return nil
+}
+
 func (keycloakClient *KeycloakClient) GetIdentityProvider(ctx context.Context, realm, alias string) (*IdentityProvider, error) {
 	var identityProvider IdentityProvider
 	identityProvider.Realm = realm
@@ -92,6 +106,21 @@ func (keycloakClient *KeycloakClient) GetIdentityProvider(ctx context.Context, r
 	return &identityProvider, nil
 }
 
+func (keycloakClient *KeycloakClient) GetIdentityProviders(ctx context.Context, realm string) ([]*IdentityProvider, error) {
+	var identityProviders []*IdentityProvider
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/identity-provider/instances", realm), &identityProviders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identityProvider := range identityProviders {
+		identityProvider.Realm = realm
+	}
+
+	return identityProviders, nil
+}
+
 func (keycloakClient *KeycloakClient) UpdateIdentityProvider(ctx context.Context, identityProvider *IdentityProvider) error {
 	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/identity-provider/instances/%s", identityProvider.Realm, identityProvider.Alias), identityProvider)
 }
@@ -45,3 +45,16 @@ func (identityProviderPermissions *IdentityProviderPermissions) GetTokenExchange
 		return "", fmt.Errorf("identity provider permissions are not enabled, thus can not return the linked 'token-exchange' scope based permission")
 	}
 }
+
+func (identityProviderPermissions *IdentityProviderPermissions) GetScopePermissionId(scope string) (string, error) {
+	if !identityProviderPermissions.Enabled {
+		return "", fmt.Errorf("identity provider permissions are not enabled, thus can not return the linked '%s' scope based permission", scope)
+	}
+
+	permissionId, ok := identityProviderPermissions.ScopePermissions[scope].(string)
+	if !ok {
+		return "", fmt.Errorf("identity provider permissions do not contain a '%s' scope based permission", scope)
+	}
+
+	return permissionId, nil
+}
@@ -0,0 +1,248 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+type KerberosUserFederation struct {
+	Id      string
+	Name    string
+	RealmId string
+
+	Enabled  bool
+	Priority int
+
+	KerberosRealm   string
+	ServerPrincipal string
+	KeyTab          string
+	Debug           bool
+
+	AllowPasswordAuthentication bool
+	EditMode                    string // can be "READ_ONLY" or "UNSYNCED", and is only used when password authentication is allowed
+	UpdateProfileFirstLogin     bool
+
+	CachePolicy    string
+	MaxLifespan    string // duration string (ex: 1h30m)
+	EvictionDay    *int
+	EvictionHour   *int
+	EvictionMinute *int
+}
+
+func convertFromKerberosUserFederationToComponent(kerberos *KerberosUserFederation) (*component, error) {
+	componentConfig := map[string][]string{
+		"enabled": {
+			strconv.FormatBool(kerberos.Enabled),
+		},
+		"priority": {
+			strconv.Itoa(kerberos.Priority),
+		},
+		"kerberosRealm": {
+			kerberos.KerberosRealm,
+		},
+		"serverPrincipal": {
+			kerberos.ServerPrincipal,
+		},
+		"keyTab": {
+			kerberos.KeyTab,
+		},
+		"debug": {
+			strconv.FormatBool(kerberos.Debug),
+		},
+		"allowPasswordAuthentication": {
+			strconv.FormatBool(kerberos.AllowPasswordAuthentication),
+		},
+		"updateProfileFirstLogin": {
+			strconv.FormatBool(kerberos.UpdateProfileFirstLogin),
+		},
+		"cachePolicy": {
+			kerberos.CachePolicy,
+		},
+	}
+
+	if kerberos.AllowPasswordAuthentication {
+		componentConfig["editMode"] = []string{kerberos.EditMode}
+	} else {
+		componentConfig["editMode"] = []string{}
+	}
+
+	componentConfig["evictionHour"] = []string{}
+	componentConfig["evictionMinute"] = []string{}
+	componentConfig["evictionDay"] = []string{}
+	componentConfig["maxLifespan"] = []string{}
+
+	if kerberos.CachePolicy != "" {
+		if kerberos.EvictionHour != nil {
+			componentConfig["evictionHour"] = []string{strconv.Itoa(*kerberos.EvictionHour)}
+		}
+		if kerberos.EvictionMinute != nil {
+			componentConfig["evictionMinute"] = []string{strconv.Itoa(*kerberos.EvictionMinute)}
+		}
+		if kerberos.EvictionDay != nil {
+			componentConfig["evictionDay"] = []string{strconv.Itoa(*kerberos.EvictionDay)}
+		}
+
+		if kerberos.MaxLifespan != "" {
+			maxLifespanMs, err := getMillisecondsFromDurationString(kerberos.MaxLifespan)
+			if err != nil {
+				return nil, err
+			}
+			componentConfig["maxLifespan"] = []string{maxLifespanMs}
+		}
+	}
+
+	return &component{
+		Id:           kerberos.Id,
+		Name:         kerberos.Name,
+		ProviderId:   "kerberos",
+		ProviderType: userStorageProviderType,
+		ParentId:     kerberos.RealmId,
+		Config:       componentConfig,
+	}, nil
+}
+
+func convertFromComponentToKerberosUserFederation(component *component) (*KerberosUserFederation, error) {
+	enabled, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("enabled"))
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := atoiAndTreatEmptyStringAsZero(component.getConfig("priority"))
+	if err != nil {
+		return nil, err
+	}
+
+	debug, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("debug"))
+	if err != nil {
+		return nil, err
+	}
+
+	allowPasswordAuthentication, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("allowPasswordAuthentication"))
+	if err != nil {
+		return nil, err
+	}
+
+	updateProfileFirstLogin, err := parseBoolAndTreatEmptyStringAsFalse(component.getConfig("updateProfileFirstLogin"))
+	if err != nil {
+		return nil, err
+	}
+
+	kerberos := &KerberosUserFederation{
+		Id:      component.Id,
+		Name:    component.Name,
+		RealmId: component.ParentId,
+
+		Enabled:  enabled,
+		Priority: priority,
+
+		KerberosRealm:   component.getConfig("kerberosRealm"),
+		ServerPrincipal: component.getConfig("serverPrincipal"),
+		KeyTab:          component.getConfig("keyTab"),
+		Debug:           debug,
+
+		AllowPasswordAuthentication: allowPasswordAuthentication,
+		EditMode:                    component.getConfig("editMode"),
+		UpdateProfileFirstLogin:     updateProfileFirstLogin,
+
+		CachePolicy: component.getConfig("cachePolicy"),
+	}
+
+	if maxLifespan, ok := component.getConfigOk("maxLifespan"); ok {
+		maxLifespanString, err := GetDurationStringFromMilliseconds(maxLifespan)
+		if err != nil {
+			return nil, err
+		}
+
+		kerberos.MaxLifespan = maxLifespanString
+	}
+
+	defaultEvictionValue := -1
+
+	if evictionDay, ok := component.getConfigOk("evictionDay"); ok {
+		evictionDayInt, err := atoiAndTreatEmptyStringAsZero(evictionDay)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse `evictionDay`: %w", err)
+		}
+
+		kerberos.EvictionDay = &evictionDayInt
+	} else {
+		kerberos.EvictionDay = &defaultEvictionValue
+	}
+
+	if evictionHour, ok := component.getConfigOk("evictionHour"); ok {
+		evictionHourInt, err := atoiAndTreatEmptyStringAsZero(evictionHour)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse `evictionHour`: %w", err)
+		}
+
+		kerberos.EvictionHour = &evictionHourInt
+	} else {
+		kerberos.EvictionHour = &defaultEvictionValue
+	}
+
+	if evictionMinute, ok := component.getConfigOk("evictionMinute"); ok {
+		evictionMinuteInt, err := atoiAndTreatEmptyStringAsZero(evictionMinute)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse `evictionMinute`: %w", err)
+		}
+
+		kerberos.EvictionMinute = &evictionMinuteInt
+	} else {
+		kerberos.EvictionMinute = &defaultEvictionValue
+	}
+
+	return kerberos, nil
+}
+
+func (keycloakClient *KeycloakClient) ValidateKerberosUserFederation(ctx context.Context, kerberos *KerberosUserFederation) error {
+	if !kerberos.AllowPasswordAuthentication && kerberos.EditMode != "" {
+		return fmt.Errorf("validation error: EditMode can only be set when AllowPasswordAuthentication is true")
+	}
+
+	if kerberos.AllowPasswordAuthentication && kerberos.EditMode == "" {
+		return fmt.Errorf("validation error: EditMode must be set when AllowPasswordAuthentication is true")
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) NewKerberosUserFederation(ctx context.Context, realmId string, kerberosUserFederation *KerberosUserFederation) error {
+	component, err := convertFromKerberosUserFederationToComponent(kerberosUserFederation)
+	if err != nil {
+		return err
+	}
+
+	_, location, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/components", realmId), component)
+	if err != nil {
+		return err
+	}
+
+	kerberosUserFederation.Id = getIdFromLocationHeader(location)
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) GetKerberosUserFederation(ctx context.Context, realmId, id string) (*KerberosUserFederation, error) {
+	var component *component
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, id), &component, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertFromComponentToKerberosUserFederation(component)
+}
+
+func (keycloakClient *KeycloakClient) UpdateKerberosUserFederation(ctx context.Context, realmId string, kerberosUserFederation *KerberosUserFederation) error {
+	component, err := convertFromKerberosUserFederationToComponent(kerberosUserFederation)
+	if err != nil {
+		return err
+	}
+
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, kerberosUserFederation.Id), component)
+}
+
+func (keycloakClient *KeycloakClient) DeleteKerberosUserFederation(ctx context.Context, realmId, id string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, id), nil)
+}
@@ -15,26 +15,30 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-version"
 
 	"golang.org/x/net/publicsuffix"
-
-	"github.com/hashicorp/go-retryablehttp"
 )
 
 type KeycloakClient struct {
 	baseUrl           string
 	realm             string
 	clientCredentials *ClientCredentials
+	tokenManager      *tokenManager
 	httpClient        *http.Client
-	initialLogin      bool
 	userAgent         string
 	version           *version.Version
+	versionMutex      sync.Mutex
 	additionalHeaders map[string]string
 	debug             bool
 	redHatSSO         bool
+	pageSize          int
+
+	// client policies and profiles are updated as a whole, so changes to them are serialized
+	clientPoliciesMutex sync.Mutex
 }
 
 type ClientCredentials struct {
@@ -43,9 +47,42 @@ type ClientCredentials struct {
 	Username     string
 	Password     string
 	GrantType    string
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
+	SubjectToken *SubjectToken
+
+	assertionSigner *clientAssertionSigner
+}
+
+// KeycloakClientConfig holds everything needed to create a KeycloakClient. New settings are added here rather than as
+// parameters of NewKeycloakClient; the zero value of every field keeps the previous default behavior.
+type KeycloakClientConfig struct {
+	Url          string
+	BasePath     string
+	Realm        string
+	ClientId     string
+	ClientSecret string
+	Username     string
+	Password     string
+	InitialLogin bool
+
+	// ClientTimeout is in seconds. PageSize limits the number of entities requested at once from list endpoints, and
+	// disables pagination when zero.
+	ClientTimeout int
+	PageSize      int
+
+	RootCaCertificate     string
+	TlsClientCertificate  string
+	TlsClientPrivateKey   string
+	TlsInsecureSkipVerify bool
+
+	UserAgent         string
+	RedHatSSO         bool
+	AdditionalHeaders map[string]string
+	RetryPolicy       *RetryPolicy
+
+	// At most one of these replaces the client secret or password login
+	ClientAssertion *ClientAssertion
+	ExternalToken   *ExternalToken
+	SubjectToken    *SubjectToken
 }
 
 const (
@@ -60,43 +97,65 @@ var redHatSSO7VersionMap = map[int]string{
 	4: "9.0.17",
 }
 
-func NewKeycloakClient(ctx context.Context, url, basePath, clientId, clientSecret, realm, username, password string, initialLogin bool, clientTimeout int, caCert string, tlsInsecureSkipVerify bool, userAgent string, redHatSSO bool, additionalHeaders map[string]string) (*KeycloakClient, error) {
+func NewKeycloakClient(ctx context.Context, config *KeycloakClientConfig) (*KeycloakClient, error) {
 	clientCredentials := &ClientCredentials{
-		ClientId:     clientId,
-		ClientSecret: clientSecret,
+		ClientId:     config.ClientId,
+		ClientSecret: config.ClientSecret,
 	}
-	if password != "" && username != "" {
-		clientCredentials.Username = username
-		clientCredentials.Password = password
+	if config.ClientAssertion != nil {
+		signer, err := newClientAssertionSigner(config.ClientAssertion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client assertion key: %v", err)
+		}
+		clientCredentials.assertionSigner = signer
+	}
+	if config.SubjectToken != nil {
+		clientCredentials.SubjectToken = config.SubjectToken
+		clientCredentials.GrantType = config.SubjectToken.grantType()
+	} else if config.Password != "" && config.Username != "" {
+		clientCredentials.Username = config.Username
+		clientCredentials.Password = config.Password
 		clientCredentials.GrantType = "password"
-	} else if clientSecret != "" {
+	} else if config.ClientSecret != "" || config.ClientAssertion != nil || config.TlsClientCertificate != "" {
+		// without a secret or signed JWT, the client is expected to authenticate with its TLS certificate (client-x509)
 		clientCredentials.GrantType = "client_credentials"
-	} else {
-		if initialLogin {
-			return nil, fmt.Errorf("must specify client id, username and password for password grant, or client id and secret for client credentials grant")
+	} else if config.ExternalToken == nil {
+		if config.InitialLogin {
+			return nil, fmt.Errorf("must specify client id, username and password for password grant, or client id and secret, private key or TLS client certificate for client credentials grant")
 		} else {
 			tflog.Warn(ctx, "missing required keycloak credentials, but proceeding anyways as initial_login is false")
 		}
 	}
+	if clientCredentials.GrantType != "" && config.ClientId == "" {
+		return nil, fmt.Errorf("must specify client id, unless an access token or exec command is used")
+	}
 
-	httpClient, err := newHttpClient(tlsInsecureSkipVerify, clientTimeout, caCert)
+	httpClient, err := newHttpClient(config.TlsInsecureSkipVerify, config.ClientTimeout, config.RootCaCertificate, config.TlsClientCertificate, config.TlsClientPrivateKey, config.RetryPolicy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create http client: %v", err)
 	}
 
 	keycloakClient := KeycloakClient{
-		baseUrl:           url + basePath,
+		baseUrl:           config.Url + config.BasePath,
 		clientCredentials: clientCredentials,
 		httpClient:        httpClient,
-		initialLogin:      initialLogin,
-		realm:             realm,
-		userAgent:         userAgent,
-		redHatSSO:         redHatSSO,
-		additionalHeaders: additionalHeaders,
+		realm:             config.Realm,
+		userAgent:         config.UserAgent,
+		redHatSSO:         config.RedHatSSO,
+		additionalHeaders: config.AdditionalHeaders,
+		pageSize:          config.PageSize,
+	}
+	if config.ExternalToken != nil {
+		keycloakClient.tokenManager = newTokenManager(config.ExternalToken.login, nil)
+	} else {
+		keycloakClient.tokenManager = newTokenManager(keycloakClient.login, keycloakClient.refresh)
 	}
 
-	if keycloakClient.initialLogin {
-		err = keycloakClient.login(ctx)
+	if config.InitialLogin {
+		_, err = keycloakClient.tokenManager.token(ctx)
+		if err == nil {
+			_, err = keycloakClient.serverVersion(ctx)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to perform initial login to Keycloak: %v", err)
 		}
@@ -111,17 +170,43 @@ func NewKeycloakClient(ctx context.Context, url, basePath, clientId, clientSecre
 	return &keycloakClient, nil
 }
 
-func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
-	accessTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
-	accessTokenData := keycloakClient.getAuthenticationFormData()
+func (keycloakClient *KeycloakClient) login(ctx context.Context) (*tokenResponse, error) {
+	accessTokenData, err := keycloakClient.getAuthenticationFormData()
+	if err != nil {
+		return nil, err
+	}
 
 	tflog.Debug(ctx, "Login request", map[string]interface{}{
-		"request": accessTokenData.Encode(),
+		"request": redactFormData(accessTokenData),
 	})
 
-	accessTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenUrl, strings.NewReader(accessTokenData.Encode()))
+	return keycloakClient.requestToken(ctx, accessTokenData)
+}
+
+func (keycloakClient *KeycloakClient) refresh(ctx context.Context, refreshToken string) (*tokenResponse, error) {
+	refreshTokenData := url.Values{}
+	refreshTokenData.Set("client_id", keycloakClient.clientCredentials.ClientId)
+	refreshTokenData.Set("grant_type", "refresh_token")
+	refreshTokenData.Set("refresh_token", refreshToken)
+
+	err := keycloakClient.addClientAuthentication(refreshTokenData)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Refresh request", map[string]interface{}{
+		"request": redactFormData(refreshTokenData),
+	})
+
+	return keycloakClient.requestToken(ctx, refreshTokenData)
+}
+
+func (keycloakClient *KeycloakClient) requestToken(ctx context.Context, tokenData url.Values) (*tokenResponse, error) {
+	accessTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
+
+	accessTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenUrl, strings.NewReader(tokenData.Encode()))
+	if err != nil {
+		return nil, err
 	}
 
 	for header, value := range keycloakClient.additionalHeaders {
@@ -136,33 +221,42 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 
 	accessTokenResponse, err := keycloakClient.httpClient.Do(accessTokenRequest)
 	if err != nil {
-		return err
-	}
-	if accessTokenResponse.StatusCode != http.StatusOK {
-		return fmt.Errorf("error sending POST request to %s: %s", accessTokenUrl, accessTokenResponse.Status)
+		return nil, err
 	}
 
 	defer accessTokenResponse.Body.Close()
 
+	if accessTokenResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error sending POST request to %s: %s", accessTokenUrl, accessTokenResponse.Status)
+	}
+
 	body, _ := ioutil.ReadAll(accessTokenResponse.Body)
 
-	tflog.Debug(ctx, "Login response", map[string]interface{}{
-		"response": string(body),
+	tflog.Debug(ctx, "Token response", map[string]interface{}{
+		"response": redactBody(body),
 	})
 
-	var clientCredentials ClientCredentials
-	err = json.Unmarshal(body, &clientCredentials)
+	var response tokenResponse
+	err = json.Unmarshal(body, &response)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	keycloakClient.clientCredentials.AccessToken = clientCredentials.AccessToken
-	keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
-	keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
+	return &response, nil
+}
+
+// serverVersion returns the version of the Keycloak server, fetching it from the /serverinfo endpoint on first use
+func (keycloakClient *KeycloakClient) serverVersion(ctx context.Context) (*version.Version, error) {
+	keycloakClient.versionMutex.Lock()
+	defer keycloakClient.versionMutex.Unlock()
+
+	if keycloakClient.version != nil {
+		return keycloakClient.version, nil
+	}
 
 	info, err := keycloakClient.GetServerInfo(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	serverVersion := info.SystemInfo.ServerVersion
@@ -173,7 +267,7 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 
 		if err != nil {
 			fmt.Println("Error compiling regex:", err)
-			return err
+			return nil, err
 		}
 
 		// Check if the pattern is found in serverVersion
@@ -185,13 +279,13 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 
 	v, err := version.NewVersion(serverVersion)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if keycloakClient.redHatSSO {
 		keycloakVersion, err := version.NewVersion(redHatSSO7VersionMap[v.Segments()[1]])
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		keycloakClient.version = keycloakVersion
@@ -199,88 +293,55 @@ func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
 		keycloakClient.version = v
 	}
 
-	return nil
+	return keycloakClient.version, nil
 }
 
-func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
-	refreshTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
-	refreshTokenData := keycloakClient.getAuthenticationFormData()
-
-	tflog.Debug(ctx, "Refresh request", map[string]interface{}{
-		"request": refreshTokenData.Encode(),
-	})
-
-	refreshTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, refreshTokenUrl, strings.NewReader(refreshTokenData.Encode()))
-	if err != nil {
-		return err
-	}
-
-	for header, value := range keycloakClient.additionalHeaders {
-		refreshTokenRequest.Header.Set(header, value)
-	}
-
-	refreshTokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	if keycloakClient.userAgent != "" {
-		refreshTokenRequest.Header.Set("User-Agent", keycloakClient.userAgent)
-	}
-
-	refreshTokenResponse, err := keycloakClient.httpClient.Do(refreshTokenRequest)
-	if err != nil {
-		return err
-	}
-
-	defer refreshTokenResponse.Body.Close()
-
-	body, _ := ioutil.ReadAll(refreshTokenResponse.Body)
-
-	tflog.Debug(ctx, "Refresh response", map[string]interface{}{
-		"response": string(body),
-	})
-
-	// Handle 401 "User or client no longer has role permissions for client key" until I better understand why that happens in the first place
-	if refreshTokenResponse.StatusCode == http.StatusBadRequest {
-		tflog.Debug(ctx, "Unexpected 400, attempting to log in again")
+func (keycloakClient *KeycloakClient) getAuthenticationFormData() (url.Values, error) {
+	authenticationFormData := url.Values{}
+	authenticationFormData.Set("client_id", keycloakClient.clientCredentials.ClientId)
+	authenticationFormData.Set("grant_type", keycloakClient.clientCredentials.GrantType)
 
-		return keycloakClient.login(ctx)
+	if keycloakClient.clientCredentials.GrantType == "password" {
+		authenticationFormData.Set("username", keycloakClient.clientCredentials.Username)
+		authenticationFormData.Set("password", keycloakClient.clientCredentials.Password)
+	} else if keycloakClient.clientCredentials.SubjectToken != nil {
+		err := keycloakClient.clientCredentials.SubjectToken.addFormData(authenticationFormData)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	var clientCredentials ClientCredentials
-	err = json.Unmarshal(body, &clientCredentials)
+	err := keycloakClient.addClientAuthentication(authenticationFormData)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	keycloakClient.clientCredentials.AccessToken = clientCredentials.AccessToken
-	keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
-	keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
-
-	return nil
+	return authenticationFormData, nil
 }
 
-func (keycloakClient *KeycloakClient) getAuthenticationFormData() url.Values {
-	authenticationFormData := url.Values{}
-	authenticationFormData.Set("client_id", keycloakClient.clientCredentials.ClientId)
-	authenticationFormData.Set("grant_type", keycloakClient.clientCredentials.GrantType)
+// Authenticates the client itself with either a signed JWT or a secret. Clients using neither are either public
+// or authenticate with their TLS certificate.
+func (keycloakClient *KeycloakClient) addClientAuthentication(formData url.Values) error {
+	if keycloakClient.clientCredentials.assertionSigner != nil {
+		audience := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
 
-	if keycloakClient.clientCredentials.GrantType == "password" {
-		authenticationFormData.Set("username", keycloakClient.clientCredentials.Username)
-		authenticationFormData.Set("password", keycloakClient.clientCredentials.Password)
-
-		if keycloakClient.clientCredentials.ClientSecret != "" {
-			authenticationFormData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
+		assertion, err := keycloakClient.clientCredentials.assertionSigner.sign(keycloakClient.clientCredentials.ClientId, audience, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to sign client assertion: %v", err)
 		}
 
-	} else if keycloakClient.clientCredentials.GrantType == "client_credentials" {
-		authenticationFormData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
+		formData.Set("client_assertion_type", clientAssertionType)
+		formData.Set("client_assertion", assertion)
+	} else if keycloakClient.clientCredentials.ClientSecret != "" {
+		formData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
 	}
 
-	return authenticationFormData
+	return nil
 }
 
-func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
-	tokenType := keycloakClient.clientCredentials.TokenType
-	accessToken := keycloakClient.clientCredentials.AccessToken
+func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request, token *oauthToken) {
+	tokenType := token.tokenType
+	accessToken := token.accessToken
 
 	for header, value := range keycloakClient.additionalHeaders {
 		request.Header.Set(header, value)
@@ -303,12 +364,9 @@ func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
 Sends an HTTP request and refreshes credentials on 403 or 401 errors
 */
 func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *http.Request, body []byte) ([]byte, string, error) {
-	if !keycloakClient.initialLogin {
-		keycloakClient.initialLogin = true
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return nil, "", fmt.Errorf("error logging in: %s", err)
-		}
+	token, err := keycloakClient.tokenManager.token(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("error logging in: %s", err)
 	}
 
 	requestMethod := request.Method
@@ -321,12 +379,14 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 
 	if body != nil {
 		request.Body = ioutil.NopCloser(bytes.NewReader(body))
-		requestLogArgs["body"] = string(body)
+		requestLogArgs["body"] = redactBody(body)
 	}
 
-	tflog.Debug(ctx, "Sending request", requestLogArgs)
+	keycloakClient.addRequestHeaders(request, token)
+
+	requestLogArgs["headers"] = redactHeaders(request.Header)
 
-	keycloakClient.addRequestHeaders(request)
+	tflog.Debug(ctx, "Sending request", requestLogArgs)
 
 	response, err := keycloakClient.httpClient.Do(request)
 	if err != nil {
@@ -340,12 +400,15 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 			"status": response.Status,
 		})
 
-		err := keycloakClient.refresh(ctx)
+		response.Body.Close()
+		keycloakClient.tokenManager.invalidate(token)
+
+		token, err = keycloakClient.tokenManager.token(ctx)
 		if err != nil {
 			return nil, "", fmt.Errorf("error refreshing credentials: %s", err)
 		}
 
-		keycloakClient.addRequestHeaders(request)
+		keycloakClient.addRequestHeaders(request, token)
 
 		if body != nil {
 			request.Body = ioutil.NopCloser(bytes.NewReader(body))
@@ -367,8 +430,8 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 		"status": response.Status,
 	}
 
-	if len(responseBody) != 0 && request.URL.Path != "/auth/admin/serverinfo" {
-		responseLogArgs["body"] = string(responseBody)
+	if len(responseBody) != 0 && !strings.HasSuffix(request.URL.Path, "/admin/serverinfo") {
+		responseLogArgs["body"] = redactBody(responseBody)
 	}
 
 	tflog.Debug(ctx, "Received response", responseLogArgs)
@@ -376,14 +439,7 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 	if response.StatusCode >= 400 {
 		errorMessage := fmt.Sprintf("error sending %s request to %s: %s.", request.Method, request.URL.Path, response.Status)
 
-		if len(responseBody) != 0 {
-			errorMessage = fmt.Sprintf("%s Response body: %s", errorMessage, responseBody)
-		}
-
-		return nil, "", &ApiError{
-			Code:    response.StatusCode,
-			Message: errorMessage,
-		}
+		return nil, "", newApiError(response.StatusCode, errorMessage, responseBody)
 	}
 
 	return responseBody, response.Header.Get("Location"), nil
@@ -499,7 +555,7 @@ func (keycloakClient *KeycloakClient) marshal(body interface{}) ([]byte, error)
 	return json.Marshal(body)
 }
 
-func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string) (*http.Client, error) {
+func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert, tlsClientCert, tlsClientKey string, retryPolicy *RetryPolicy) (*http.Client, error) {
 	cookieJar, err := cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -518,15 +574,61 @@ func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string)
 		transport.TLSClientConfig.RootCAs = caCertPool
 	}
 
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 1
-	retryClient.RetryWaitMin = time.Second * 1
-	retryClient.RetryWaitMax = time.Second * 3
+	if tlsClientCert != "" || tlsClientKey != "" {
+		certificate, err := loadClientCertificate(tlsClientCert, tlsClientKey)
+		if err != nil {
+			return nil, err
+		}
 
-	httpClient := retryClient.StandardClient()
-	httpClient.Timeout = time.Second * time.Duration(clientTimeout)
-	httpClient.Transport = transport
-	httpClient.Jar = cookieJar
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
+	// the timeout applies to each attempt rather than to the request as a whole, including retries
+	attemptClient := &http.Client{
+		Transport: transport,
+		Timeout:   time.Second * time.Duration(clientTimeout),
+		Jar:       cookieJar,
+	}
+
+	httpClient := &http.Client{
+		Transport: newRetryRoundTripper(retryPolicy, attemptClient),
+	}
 
 	return httpClient, nil
 }
+
+func loadClientCertificate(tlsClientCert, tlsClientKey string) (tls.Certificate, error) {
+	if tlsClientCert == "" || tlsClientKey == "" {
+		return tls.Certificate{}, fmt.Errorf("both a TLS client certificate and private key must be provided")
+	}
+
+	certPEM, err := readPEMOrFile(tlsClientCert)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to read TLS client certificate: %v", err)
+	}
+
+	keyPEM, err := readPEMOrFile(tlsClientKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to read TLS client private key: %v", err)
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load TLS client certificate: %v", err)
+	}
+
+	return certificate, nil
+}
+
+// Accepts either PEM encoded content or the path to a file containing it
+func readPEMOrFile(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
+}
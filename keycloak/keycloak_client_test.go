@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -48,8 +49,18 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 		t.Fatal("KEYCLOAK_CLIENT_TIMEOUT must be an integer")
 	}
 
-	keycloakClient, err := NewKeycloakClient(ctx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), true, clientTimeout, "", false, "", false, map[string]string{
-		"foo": "bar",
+	keycloakClient, err := NewKeycloakClient(ctx, &KeycloakClientConfig{
+		Url:           os.Getenv("KEYCLOAK_URL"),
+		ClientId:      os.Getenv("KEYCLOAK_CLIENT_ID"),
+		ClientSecret:  os.Getenv("KEYCLOAK_CLIENT_SECRET"),
+		Realm:         os.Getenv("KEYCLOAK_REALM"),
+		Username:      os.Getenv("KEYCLOAK_USER"),
+		Password:      os.Getenv("KEYCLOAK_PASSWORD"),
+		InitialLogin:  true,
+		ClientTimeout: clientTimeout,
+		AdditionalHeaders: map[string]string{
+			"foo": "bar",
+		},
 	})
 	if err != nil {
 		t.Fatalf("%s", err)
@@ -71,13 +82,14 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 		t.Fatalf("%s", err)
 	}
 
-	var oldAccessToken, oldRefreshToken, oldTokenType string
+	var oldToken *oauthToken
 
 	// A following GET for this realm will result in a 403, so we should save the current access and refresh token
 	if keycloakClient.clientCredentials.GrantType == "client_credentials" {
-		oldAccessToken = keycloakClient.clientCredentials.AccessToken
-		oldRefreshToken = keycloakClient.clientCredentials.RefreshToken
-		oldTokenType = keycloakClient.clientCredentials.TokenType
+		oldToken, err = keycloakClient.tokenManager.token(ctx)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
 	}
 
 	_, err = keycloakClient.GetRealm(ctx, realmName) // This should not fail since it will automatically refresh and try again
@@ -92,20 +104,58 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 	}
 
 	if keycloakClient.clientCredentials.GrantType == "client_credentials" {
-		newAccessToken := keycloakClient.clientCredentials.AccessToken
-		newRefreshToken := keycloakClient.clientCredentials.RefreshToken
-		newTokenType := keycloakClient.clientCredentials.TokenType
+		newToken, err := keycloakClient.tokenManager.token(ctx)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
 
-		if oldAccessToken == newAccessToken {
+		if oldToken.accessToken == newToken.accessToken {
 			t.Fatalf("expected access token to update after refresh")
 		}
 
-		if oldRefreshToken == newRefreshToken {
+		if oldToken.refreshToken == newToken.refreshToken {
 			t.Fatalf("expected refresh token to update after refresh")
 		}
 
-		if oldTokenType != newTokenType {
+		if oldToken.tokenType != newToken.tokenType {
 			t.Fatalf("expected token type to remain the same after refresh")
 		}
 	}
 }
+
+func TestNewKeycloakClient_clientIdRequired(t *testing.T) {
+	testCases := map[string]*KeycloakClientConfig{
+		"password": {
+			Url:      "http://localhost",
+			Realm:    "master",
+			Username: "admin",
+			Password: "password",
+		},
+		"client credentials": {
+			Url:          "http://localhost",
+			Realm:        "master",
+			ClientSecret: "secret",
+		},
+		"subject token": {
+			Url:          "http://localhost",
+			Realm:        "master",
+			SubjectToken: &SubjectToken{Token: "token"},
+		},
+	}
+
+	for name, config := range testCases {
+		_, err := NewKeycloakClient(context.Background(), config)
+		if err == nil || !strings.Contains(err.Error(), "client id") {
+			t.Errorf("expected an error about the missing client id for %s, got %v", name, err)
+		}
+	}
+
+	_, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           "http://localhost",
+		Realm:         "master",
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Errorf("expected no client id to be required with an access token, got %v", err)
+	}
+}
@@ -42,7 +42,9 @@ func convertFromComponentConfig(originalMap map[string][]string) map[string]stri
 	convertedMap := make(map[string]string)
 
 	for key, values := range originalMap {
-		convertedMap[key] = values[0]
+		if len(values) != 0 {
+			convertedMap[key] = values[0]
+		}
 	}
 
 	return convertedMap
@@ -80,6 +82,33 @@ func (keycloakClient *KeycloakClient) GetLdapCustomMapper(ctx context.Context, r
 	return convertFromComponentToLdapCustomMapper(component, realmId)
 }
 
+// GetLdapCustomMappers returns every mapper of an LDAP user federation provider, regardless of its provider ID
+func (keycloakClient *KeycloakClient) GetLdapCustomMappers(ctx context.Context, realmId, ldapUserFederationId string) ([]*LdapCustomMapper, error) {
+	var components []*component
+
+	params := map[string]string{
+		"parent": ldapUserFederationId,
+		"type":   "org.keycloak.storage.ldap.mappers.LDAPStorageMapper",
+	}
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components", realmId), &components, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var ldapCustomMappers []*LdapCustomMapper
+	for _, component := range components {
+		ldapCustomMapper, err := convertFromComponentToLdapCustomMapper(component, realmId)
+		if err != nil {
+			return nil, err
+		}
+
+		ldapCustomMappers = append(ldapCustomMappers, ldapCustomMapper)
+	}
+
+	return ldapCustomMappers, nil
+}
+
 func (keycloakClient *KeycloakClient) UpdateLdapCustomMapper(ctx context.Context, ldapCustomMapper *LdapCustomMapper) error {
 	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/components/%s", ldapCustomMapper.RealmId, ldapCustomMapper.Id), convertFromLdapCustomMapperToComponent(ldapCustomMapper))
 }
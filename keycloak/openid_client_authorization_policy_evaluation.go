@@ -0,0 +1,94 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type OpenidClientAuthorizationPolicyEvaluationScope struct {
+	Name string `json:"name"`
+}
+
+type OpenidClientAuthorizationPolicyEvaluationResource struct {
+	Id     string                                           `json:"_id,omitempty"`
+	Name   string                                           `json:"name,omitempty"`
+	Scopes []OpenidClientAuthorizationPolicyEvaluationScope `json:"scopes,omitempty"`
+}
+
+type OpenidClientAuthorizationPolicyEvaluationContext struct {
+	Attributes map[string]string `json:"attributes"`
+}
+
+type OpenidClientAuthorizationPolicyEvaluationRequest struct {
+	RealmId          string                                              `json:"-"`
+	ResourceServerId string                                              `json:"-"`
+	UserId           string                                              `json:"userId"`
+	ClientId         string                                              `json:"clientId,omitempty"`
+	RoleIds          []string                                            `json:"roleIds,omitempty"`
+	Resources        []OpenidClientAuthorizationPolicyEvaluationResource `json:"resources"`
+	Context          OpenidClientAuthorizationPolicyEvaluationContext    `json:"context"`
+	Entitlements     bool                                                `json:"entitlements"`
+}
+
+type OpenidClientAuthorizationPolicyEvaluationPolicy struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// OpenidClientAuthorizationPolicyEvaluationPolicyResult is the decision of a single policy. At the top level of a result
+// this is a permission, and its associated policies are the policies that voted on it.
+type OpenidClientAuthorizationPolicyEvaluationPolicyResult struct {
+	Policy             OpenidClientAuthorizationPolicyEvaluationPolicy         `json:"policy"`
+	Status             string                                                  `json:"status"`
+	Scopes             []string                                                `json:"scopes"`
+	AssociatedPolicies []OpenidClientAuthorizationPolicyEvaluationPolicyResult `json:"associatedPolicies"`
+}
+
+type OpenidClientAuthorizationPolicyEvaluationResult struct {
+	Resource      OpenidClientAuthorizationPolicyEvaluationResource       `json:"resource"`
+	Status        string                                                  `json:"status"`
+	AllowedScopes []OpenidClientAuthorizationPolicyEvaluationScope        `json:"allowedScopes"`
+	Policies      []OpenidClientAuthorizationPolicyEvaluationPolicyResult `json:"policies"`
+}
+
+type OpenidClientAuthorizationPolicyEvaluationResponse struct {
+	Status  string                                            `json:"status"`
+	Results []OpenidClientAuthorizationPolicyEvaluationResult `json:"results"`
+}
+
+// EvaluateOpenidClientAuthorizationPolicies evaluates the permissions of a resource server for the given identity. Resources
+// without an ID are looked up by their name, as Keycloak only evaluates resources by ID.
+func (keycloakClient *KeycloakClient) EvaluateOpenidClientAuthorizationPolicies(ctx context.Context, request *OpenidClientAuthorizationPolicyEvaluationRequest) (*OpenidClientAuthorizationPolicyEvaluationResponse, error) {
+	for i, resource := range request.Resources {
+		if resource.Id != "" {
+			continue
+		}
+
+		var resources []OpenidClientAuthorizationPolicyEvaluationResource
+		params := map[string]string{"name": resource.Name, "exactName": "true"}
+		err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/resource", request.RealmId, request.ResourceServerId), &resources, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(resources) == 0 {
+			return nil, fmt.Errorf("unable to find client authorization resource with name %s", resource.Name)
+		}
+
+		request.Resources[i].Id = resources[0].Id
+	}
+
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/policy/evaluate", request.RealmId, request.ResourceServerId), request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response OpenidClientAuthorizationPolicyEvaluationResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
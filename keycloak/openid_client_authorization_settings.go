@@ -0,0 +1,324 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Keys holding IDs that are generated by Keycloak, and which differ between otherwise identical resource servers
+var authorizationSettingsIdKeys = map[string]bool{
+	"id":  true,
+	"_id": true,
+}
+
+// Top level keys identifying the client the resource server belongs to, which are ignored when importing settings
+var authorizationSettingsClientKeys = []string{"id", "clientId", "name"}
+
+// The lists of named entities within a resource server representation
+var authorizationSettingsEntityKeys = []string{"resources", "scopes", "policies"}
+
+type authorizationSettingsEntity struct {
+	Id         string `json:"id"`
+	ResourceId string `json:"_id"`
+	Name       string `json:"name"`
+}
+
+type authorizationSettingsEntities struct {
+	Resources []authorizationSettingsEntity `json:"resources"`
+	Scopes    []authorizationSettingsEntity `json:"scopes"`
+	Policies  []authorizationSettingsEntity `json:"policies"`
+}
+
+// ImportOpenidClientAuthorizationSettings imports a resource server representation, as exported by Keycloak. Resources, scopes
+// and policies are matched by name, so existing entities are updated and new ones are created.
+func (keycloakClient *KeycloakClient) ImportOpenidClientAuthorizationSettings(ctx context.Context, realmId, resourceServerId, settings string) error {
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/import", realmId, resourceServerId), json.RawMessage(settings))
+
+	return err
+}
+
+// GetOpenidClientAuthorizationSettings exports the resource server representation of a client, normalized with
+// NormalizeOpenidClientAuthorizationSettings. When declared is not empty, only the resources, scopes and policies
+// declared within it are exported, so entities managed elsewhere are left out, and only the keys declared within it
+// are kept, so the defaults filled in by Keycloak don't show up as changes.
+func (keycloakClient *KeycloakClient) GetOpenidClientAuthorizationSettings(ctx context.Context, realmId, resourceServerId, declared string) (string, error) {
+	body, err := keycloakClient.getRaw(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/settings", realmId, resourceServerId), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var resourceServer map[string]interface{}
+	err = json.Unmarshal(body, &resourceServer)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse authorization settings: %v", err)
+	}
+
+	if declared != "" {
+		entities, err := parseAuthorizationSettingsEntities(declared)
+		if err != nil {
+			return "", err
+		}
+
+		filterAuthorizationSettingsEntities(resourceServer, "resources", entities.Resources)
+		filterAuthorizationSettingsEntities(resourceServer, "scopes", entities.Scopes)
+		filterAuthorizationSettingsEntities(resourceServer, "policies", entities.Policies)
+
+		var declaredResourceServer map[string]interface{}
+		err = json.Unmarshal([]byte(declared), &declaredResourceServer)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse authorization settings: %v", err)
+		}
+
+		resourceServer = projectAuthorizationSettings(resourceServer, declaredResourceServer).(map[string]interface{})
+	}
+
+	return normalizeAuthorizationSettings(resourceServer)
+}
+
+// DeleteOpenidClientAuthorizationSettingsEntities deletes the resources, scopes and policies declared in settings that are
+// not also declared in keep. Importing settings never removes entities, so this is used to remove entities that are no
+// longer managed.
+func (keycloakClient *KeycloakClient) DeleteOpenidClientAuthorizationSettingsEntities(ctx context.Context, realmId, resourceServerId, settings, keep string) error {
+	declared, err := parseAuthorizationSettingsEntities(settings)
+	if err != nil {
+		return err
+	}
+
+	kept, err := parseAuthorizationSettingsEntities(keep)
+	if err != nil {
+		return err
+	}
+
+	var existing authorizationSettingsEntities
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/settings", realmId, resourceServerId), &existing, nil)
+	if err != nil {
+		return err
+	}
+
+	// policies are deleted first, as permissions reference resources and scopes
+	for _, policy := range removedAuthorizationSettingsEntities(existing.Policies, declared.Policies, kept.Policies) {
+		err = keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/clients/%s/authz/resource-server/policy/%s", realmId, resourceServerId, policy.Id), nil)
+		if err != nil && !ErrorIs404(err) {
+			return err
+		}
+	}
+
+	for _, resource := range removedAuthorizationSettingsEntities(existing.Resources, declared.Resources, kept.Resources) {
+		err = keycloakClient.DeleteOpenidClientAuthorizationResource(ctx, realmId, resourceServerId, resource.ResourceId)
+		if err != nil && !ErrorIs404(err) {
+			return err
+		}
+	}
+
+	for _, scope := range removedAuthorizationSettingsEntities(existing.Scopes, declared.Scopes, kept.Scopes) {
+		err = keycloakClient.DeleteOpenidClientAuthorizationScope(ctx, realmId, resourceServerId, scope.Id)
+		if err != nil && !ErrorIs404(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NormalizeOpenidClientAuthorizationSettings re-encodes a resource server representation so that equivalent settings always
+// result in the same JSON: keys are sorted, resources, scopes and policies are sorted by name, empty lists and objects are
+// removed, and the IDs generated by Keycloak are removed.
+func NormalizeOpenidClientAuthorizationSettings(settings string) (string, error) {
+	var resourceServer map[string]interface{}
+
+	err := json.Unmarshal([]byte(settings), &resourceServer)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse authorization settings: %v", err)
+	}
+
+	return normalizeAuthorizationSettings(resourceServer)
+}
+
+func normalizeAuthorizationSettings(resourceServer map[string]interface{}) (string, error) {
+	for _, key := range authorizationSettingsClientKeys {
+		delete(resourceServer, key)
+	}
+
+	resourceServer = stripJsonKeys(resourceServer, authorizationSettingsIdKeys).(map[string]interface{})
+	resourceServer = stripEmptyJsonValues(resourceServer).(map[string]interface{})
+
+	for _, key := range authorizationSettingsEntityKeys {
+		if entities, ok := resourceServer[key].([]interface{}); ok {
+			sort.SliceStable(entities, func(i, j int) bool {
+				return authorizationSettingsEntityName(entities[i]) < authorizationSettingsEntityName(entities[j])
+			})
+		}
+	}
+
+	normalized, err := json.Marshal(resourceServer)
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+// stripEmptyJsonValues recursively removes the keys of a decoded JSON object holding null, or an empty list or object.
+// Keycloak leaves some of these out of its representations, while others are always included.
+func stripEmptyJsonValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			child = stripEmptyJsonValues(child)
+
+			switch c := child.(type) {
+			case nil:
+				continue
+			case map[string]interface{}:
+				if len(c) == 0 {
+					continue
+				}
+			case []interface{}:
+				if len(c) == 0 {
+					continue
+				}
+			}
+
+			stripped[key] = child
+		}
+
+		return stripped
+	case []interface{}:
+		stripped := make([]interface{}, len(v))
+		for i, child := range v {
+			stripped[i] = stripEmptyJsonValues(child)
+		}
+
+		return stripped
+	default:
+		return v
+	}
+}
+
+// projectAuthorizationSettings keeps only the keys of actual that are also present in declared. Entities within lists
+// are matched by name, or by their position when they have none. Entities that are not declared are kept as they are,
+// so they still show up as changes.
+func projectAuthorizationSettings(actual, declared interface{}) interface{} {
+	switch d := declared.(type) {
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+
+		projected := make(map[string]interface{}, len(d))
+		for key, declaredChild := range d {
+			if actualChild, ok := actualMap[key]; ok {
+				projected[key] = projectAuthorizationSettings(actualChild, declaredChild)
+			}
+		}
+
+		return projected
+	case []interface{}:
+		actualList, ok := actual.([]interface{})
+		if !ok {
+			return actual
+		}
+
+		projected := make([]interface{}, len(actualList))
+		for i, actualChild := range actualList {
+			projected[i] = actualChild
+
+			if declaredChild, ok := declaredAuthorizationSettingsElement(d, actualChild, i); ok {
+				projected[i] = projectAuthorizationSettings(actualChild, declaredChild)
+			}
+		}
+
+		return projected
+	default:
+		return actual
+	}
+}
+
+func declaredAuthorizationSettingsElement(declared []interface{}, actual interface{}, index int) (interface{}, bool) {
+	if name := authorizationSettingsEntityName(actual); name != "" {
+		for _, element := range declared {
+			if authorizationSettingsEntityName(element) == name {
+				return element, true
+			}
+		}
+
+		return nil, false
+	}
+
+	if index < len(declared) {
+		return declared[index], true
+	}
+
+	return nil, false
+}
+
+// filterAuthorizationSettingsEntities removes the entities under key whose name is not declared
+func filterAuthorizationSettingsEntities(resourceServer map[string]interface{}, key string, declared []authorizationSettingsEntity) {
+	entities, ok := resourceServer[key].([]interface{})
+	if !ok {
+		return
+	}
+
+	names := make(map[string]bool, len(declared))
+	for _, entity := range declared {
+		names[entity.Name] = true
+	}
+
+	filtered := make([]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		if names[authorizationSettingsEntityName(entity)] {
+			filtered = append(filtered, entity)
+		}
+	}
+
+	resourceServer[key] = filtered
+}
+
+func authorizationSettingsEntityName(entity interface{}) string {
+	if entityMap, ok := entity.(map[string]interface{}); ok {
+		if name, ok := entityMap["name"].(string); ok {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func parseAuthorizationSettingsEntities(settings string) (*authorizationSettingsEntities, error) {
+	var entities authorizationSettingsEntities
+
+	if settings == "" {
+		return &entities, nil
+	}
+
+	err := json.Unmarshal([]byte(settings), &entities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse authorization settings: %v", err)
+	}
+
+	return &entities, nil
+}
+
+// removedAuthorizationSettingsEntities returns the existing entities whose name is declared, but not kept
+func removedAuthorizationSettingsEntities(existing, declared, kept []authorizationSettingsEntity) []authorizationSettingsEntity {
+	names := make(map[string]bool, len(declared))
+	for _, entity := range declared {
+		names[entity.Name] = true
+	}
+	for _, entity := range kept {
+		delete(names, entity.Name)
+	}
+
+	var removed []authorizationSettingsEntity
+	for _, entity := range existing {
+		if names[entity.Name] {
+			removed = append(removed, entity)
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,115 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeOpenidClientAuthorizationSettings(t *testing.T) {
+	exported := `{"id": "c1", "clientId": "c1", "name": "web", "policyEnforcementMode": "ENFORCING", "decisionStrategy": "UNANIMOUS", "scopes": [{"id": "s2", "name": "write"}, {"id": "s1", "name": "read"}], "resources": [{"_id": "r1", "name": "docs", "scopes": [{"name": "read"}]}], "policies": []}`
+	authored := `{"decisionStrategy": "UNANIMOUS", "policyEnforcementMode": "ENFORCING", "resources": [{"scopes": [{"name": "read"}], "name": "docs"}], "scopes": [{"name": "read"}, {"name": "write"}]}`
+
+	exportedNormalized, err := NormalizeOpenidClientAuthorizationSettings(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authoredNormalized, err := NormalizeOpenidClientAuthorizationSettings(authored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exportedNormalized != authoredNormalized {
+		t.Fatalf("expected equivalent settings to be normalized to the same JSON, got %s and %s", exportedNormalized, authoredNormalized)
+	}
+}
+
+func TestFilterAuthorizationSettingsEntities(t *testing.T) {
+	resourceServer := map[string]interface{}{
+		"policies": []interface{}{
+			map[string]interface{}{"name": "Default Policy"},
+			map[string]interface{}{"name": "admins"},
+		},
+	}
+
+	filterAuthorizationSettingsEntities(resourceServer, "policies", []authorizationSettingsEntity{{Name: "admins"}})
+
+	normalized, err := normalizeAuthorizationSettings(resourceServer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"policies":[{"name":"admins"}]}`
+	if normalized != expected {
+		t.Fatalf("expected undeclared policies to be filtered, got %s", normalized)
+	}
+}
+
+func TestRemovedAuthorizationSettingsEntities(t *testing.T) {
+	existing := []authorizationSettingsEntity{{Id: "1", Name: "a"}, {Id: "2", Name: "b"}, {Id: "3", Name: "c"}}
+	declared := []authorizationSettingsEntity{{Name: "a"}, {Name: "b"}}
+	kept := []authorizationSettingsEntity{{Name: "b"}}
+
+	removed := removedAuthorizationSettingsEntities(existing, declared, kept)
+	if len(removed) != 1 || removed[0].Id != "1" {
+		t.Fatalf("expected only entity a to be removed, got %v", removed)
+	}
+}
+
+func TestGetOpenidClientAuthorizationSettings_minimalDeclaration(t *testing.T) {
+	exported := `{
+		"id": "c1",
+		"clientId": "c1",
+		"name": "web",
+		"allowRemoteResourceManagement": true,
+		"policyEnforcementMode": "ENFORCING",
+		"decisionStrategy": "UNANIMOUS",
+		"resources": [
+			{"_id": "r1", "name": "documents", "ownerManagedAccess": false, "attributes": {}, "uris": ["/documents/*"], "scopes": [{"id": "s1", "name": "read"}]},
+			{"_id": "r2", "name": "Default Resource", "type": "urn:web:resources:default", "ownerManagedAccess": false, "attributes": {}, "uris": ["/*"]}
+		],
+		"policies": [
+			{"id": "p1", "name": "admins", "type": "role", "logic": "POSITIVE", "decisionStrategy": "UNANIMOUS", "config": {"roles": "[{\"id\":\"admin\",\"required\":false}]"}},
+			{"id": "p2", "name": "Default Policy", "type": "js", "logic": "POSITIVE", "decisionStrategy": "AFFIRMATIVE", "config": {}}
+		],
+		"scopes": [{"id": "s1", "name": "read", "iconUri": "", "displayName": ""}]
+	}`
+	declared := `{
+		"policyEnforcementMode": "ENFORCING",
+		"resources": [{"name": "documents", "attributes": {}, "uris": ["/documents/*"], "scopes": [{"name": "read"}]}],
+		"policies": [{"name": "admins", "type": "role", "config": {"roles": "[{\"id\":\"admin\",\"required\":false}]"}}],
+		"scopes": [{"name": "read"}]
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(exported))
+	}))
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := keycloakClient.GetOpenidClientAuthorizationSettings(context.Background(), "master", "c1", declared)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	declaredNormalized, err := NormalizeOpenidClientAuthorizationSettings(declared)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if settings != declaredNormalized {
+		t.Fatalf("expected the export to match the minimal declaration, got %s and %s", settings, declaredNormalized)
+	}
+}
@@ -0,0 +1,54 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+)
+
+// OpenidClientExampleTokens holds the claims of the tokens Keycloak would issue to a user for a client and scope
+type OpenidClientExampleTokens struct {
+	AccessToken map[string]interface{}
+	IdToken     map[string]interface{}
+	UserInfo    map[string]interface{}
+}
+
+func (keycloakClient *KeycloakClient) generateOpenidClientExampleToken(ctx context.Context, realmId, clientId, userId, scope, tokenType string) (map[string]interface{}, error) {
+	var claims map[string]interface{}
+
+	params := map[string]string{
+		"userId": userId,
+		"scope":  scope,
+	}
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/evaluate-scopes/generate-example-%s", realmId, clientId, tokenType), &claims, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// GenerateOpenidClientExampleTokens returns the claims of the access token, ID token and user info that would be issued
+// to a user when authenticating with a client using the given scope, without the user having to log in.
+func (keycloakClient *KeycloakClient) GenerateOpenidClientExampleTokens(ctx context.Context, realmId, clientId, userId, scope string) (*OpenidClientExampleTokens, error) {
+	accessToken, err := keycloakClient.generateOpenidClientExampleToken(ctx, realmId, clientId, userId, scope, "access-token")
+	if err != nil {
+		return nil, err
+	}
+
+	idToken, err := keycloakClient.generateOpenidClientExampleToken(ctx, realmId, clientId, userId, scope, "id-token")
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo, err := keycloakClient.generateOpenidClientExampleToken(ctx, realmId, clientId, userId, scope, "userinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenidClientExampleTokens{
+		AccessToken: accessToken,
+		IdToken:     idToken,
+		UserInfo:    userInfo,
+	}, nil
+}
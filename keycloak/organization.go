@@ -0,0 +1,129 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+)
+
+type OrganizationDomain struct {
+	Name     string `json:"name"`
+	Verified bool   `json:"verified"`
+}
+
+type Organization struct {
+	Id          string                `json:"id,omitempty"`
+	RealmId     string                `json:"-"`
+	Name        string                `json:"name"`
+	Alias       string                `json:"alias,omitempty"`
+	Enabled     bool                  `json:"enabled"`
+	Description string                `json:"description"`
+	RedirectUrl string                `json:"redirectUrl,omitempty"`
+	Attributes  map[string][]string   `json:"attributes"`
+	Domains     []*OrganizationDomain `json:"domains"`
+}
+
+// organizationsSupported returns an error for Keycloak versions that do not support organizations
+func (keycloakClient *KeycloakClient) organizationsSupported(ctx context.Context) error {
+	ok, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, Version_25)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("organizations are only supported by Keycloak 25 and later")
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) NewOrganization(ctx context.Context, organization *Organization) error {
+	if err := keycloakClient.organizationsSupported(ctx); err != nil {
+		return err
+	}
+
+	_, location, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/organizations", organization.RealmId), organization)
+	if err != nil {
+		return err
+	}
+
+	organization.Id = getIdFromLocationHeader(location)
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) GetOrganization(ctx context.Context, realmId, id string) (*Organization, error) {
+	var organization Organization
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s", realmId, id), &organization, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	organization.RealmId = realmId
+
+	return &organization, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateOrganization(ctx context.Context, organization *Organization) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/organizations/%s", organization.RealmId, organization.Id), organization)
+}
+
+func (keycloakClient *KeycloakClient) DeleteOrganization(ctx context.Context, realmId, id string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/organizations/%s", realmId, id), nil)
+}
+
+// Members and identity providers are added to an organization by posting their ID or alias as a JSON string
+
+func (keycloakClient *KeycloakClient) AddOrganizationMember(ctx context.Context, realmId, organizationId, userId string) error {
+	if err := keycloakClient.organizationsSupported(ctx); err != nil {
+		return err
+	}
+
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members", realmId, organizationId), userId)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationMember(ctx context.Context, realmId, organizationId, userId string) (*User, error) {
+	var user User
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members/%s", realmId, organizationId, userId), &user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user.RealmId = realmId
+
+	return &user, nil
+}
+
+func (keycloakClient *KeycloakClient) RemoveOrganizationMember(ctx context.Context, realmId, organizationId, userId string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/organizations/%s/members/%s", realmId, organizationId, userId), nil)
+}
+
+func (keycloakClient *KeycloakClient) AddOrganizationIdentityProvider(ctx context.Context, realmId, organizationId, alias string) error {
+	if err := keycloakClient.organizationsSupported(ctx); err != nil {
+		return err
+	}
+
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/organizations/%s/identity-providers", realmId, organizationId), alias)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationIdentityProvider(ctx context.Context, realmId, organizationId, alias string) (*IdentityProvider, error) {
+	var identityProvider IdentityProvider
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/organizations/%s/identity-providers/%s", realmId, organizationId, alias), &identityProvider, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	identityProvider.Realm = realmId
+
+	return &identityProvider, nil
+}
+
+func (keycloakClient *KeycloakClient) RemoveOrganizationIdentityProvider(ctx context.Context, realmId, organizationId, alias string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/organizations/%s/identity-providers/%s", realmId, organizationId, alias), nil)
+}
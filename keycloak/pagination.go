@@ -0,0 +1,58 @@
+package keycloak
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+)
+
+// DefaultPageSize is the number of results requested per page when no page size was configured
+const DefaultPageSize = 100
+
+func (keycloakClient *KeycloakClient) getPageSize() int {
+	if keycloakClient.pageSize <= 0 {
+		return DefaultPageSize
+	}
+
+	return keycloakClient.pageSize
+}
+
+// getPaginated fetches every page of a list endpoint supporting the first and max query parameters. Without them,
+// Keycloak either returns every result at once, or only the first page of its own default size. Older servers ignore
+// first and max, and return the full list for every request, so fetching stops as soon as a page is empty or starts
+// with the same element as the previous page.
+func getPaginated[T any](ctx context.Context, keycloakClient *KeycloakClient, path string, params map[string]string) ([]T, error) {
+	var results []T
+
+	pageSize := keycloakClient.getPageSize()
+
+	pageParams := map[string]string{}
+	for key, value := range params {
+		pageParams[key] = value
+	}
+	pageParams["max"] = strconv.Itoa(pageSize)
+
+	var previousPage []T
+
+	for first := 0; ; first += pageSize {
+		var page []T
+
+		pageParams["first"] = strconv.Itoa(first)
+
+		err := keycloakClient.get(ctx, path, &page, pageParams)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 || (len(previousPage) != 0 && reflect.DeepEqual(page[0], previousPage[0])) {
+			return results, nil
+		}
+
+		results = append(results, page...)
+		previousPage = page
+
+		if len(page) < pageSize {
+			return results, nil
+		}
+	}
+}
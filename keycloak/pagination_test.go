@@ -0,0 +1,144 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func newPaginatedUsersServer(t *testing.T, total int, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		first, err := strconv.Atoi(r.URL.Query().Get("first"))
+		if err != nil {
+			t.Errorf("expected first query parameter, got %s", r.URL.RawQuery)
+		}
+
+		max, err := strconv.Atoi(r.URL.Query().Get("max"))
+		if err != nil {
+			t.Errorf("expected max query parameter, got %s", r.URL.RawQuery)
+		}
+
+		var users []*User
+		for i := first; i < total && i < first+max; i++ {
+			users = append(users, &User{Id: strconv.Itoa(i), Username: fmt.Sprintf("user-%d", i)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(users)
+	}))
+}
+
+func TestGetPaginated(t *testing.T) {
+	testCases := map[int]int{
+		0:   1,
+		99:  1,
+		100: 2,
+		250: 3,
+	}
+
+	for total, expectedRequests := range testCases {
+		requests := 0
+
+		server := newPaginatedUsersServer(t, total, &requests)
+
+		keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+			Url:           server.URL,
+			Realm:         "master",
+			ClientTimeout: 5,
+			ExternalToken: &ExternalToken{AccessToken: "token"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		users, err := keycloakClient.GetUsers(context.Background(), "master")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		server.Close()
+
+		if len(users) != total {
+			t.Errorf("expected %d users, got %d", total, len(users))
+		}
+
+		for i, user := range users {
+			if user.Id != strconv.Itoa(i) || user.RealmId != "master" {
+				t.Errorf("unexpected user at position %d: %+v", i, user)
+			}
+		}
+
+		if requests != expectedRequests {
+			t.Errorf("expected %d requests for %d users, got %d", expectedRequests, total, requests)
+		}
+	}
+}
+
+func TestGetPaginated_pageSize(t *testing.T) {
+	requests := 0
+
+	server := newPaginatedUsersServer(t, 25, &requests)
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		PageSize:      10,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := keycloakClient.GetGroupMembers(context.Background(), "master", "group")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 25 || requests != 3 {
+		t.Fatalf("expected 25 users in 3 requests, got %d users in %d requests", len(users), requests)
+	}
+}
+
+func TestGetPaginated_firstAndMaxIgnored(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var users []*User
+		for i := 0; i < 25 && requests <= 10; i++ {
+			users = append(users, &User{Id: strconv.Itoa(i), Username: fmt.Sprintf("user-%d", i)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(users)
+	}))
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		PageSize:      10,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := keycloakClient.GetGroupMembers(context.Background(), "master", "group")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 25 || requests != 2 {
+		t.Fatalf("expected 25 users in 2 requests, got %d users in %d requests", len(users), requests)
+	}
+}
@@ -30,6 +30,9 @@ type Realm struct {
 	DisplayNameHtml   string `json:"displayNameHtml"`
 	UserManagedAccess bool   `json:"userManagedAccessAllowed"`
 
+	// Only sent when set, since versions before 25 do not know about organizations
+	OrganizationsEnabled *bool `json:"organizationsEnabled,omitempty"`
+
 	// Login Config
 	RegistrationAllowed         bool   `json:"registrationAllowed"`
 	RegistrationEmailAsUsername bool   `json:"registrationEmailAsUsername"`
@@ -233,6 +236,12 @@ func (keycloakClient *KeycloakClient) ValidateRealm(ctx context.Context, realm *
 		return fmt.Errorf("validation error: SslRequired should be 'none', 'external' or 'all'")
 	}
 
+	if realm.OrganizationsEnabled != nil && *realm.OrganizationsEnabled {
+		if err := keycloakClient.organizationsSupported(ctx); err != nil {
+			return fmt.Errorf("validation error: %v", err)
+		}
+	}
+
 	// validate if the given theme exists on the server. the keycloak API allows you to use any random string for a theme
 	serverInfo, err := keycloakClient.GetServerInfo(ctx)
 	if err != nil {
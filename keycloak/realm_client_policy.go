@@ -0,0 +1,154 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type RealmClientPolicyCondition struct {
+	Condition     string                 `json:"condition"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+type RealmClientPolicy struct {
+	RealmId     string                        `json:"-"`
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	Enabled     bool                          `json:"enabled"`
+	Conditions  []*RealmClientPolicyCondition `json:"conditions"`
+	Profiles    []string                      `json:"profiles"`
+}
+
+type realmClientPolicies struct {
+	Policies []*RealmClientPolicy `json:"policies"`
+}
+
+func (keycloakClient *KeycloakClient) getRealmClientPolicies(ctx context.Context, realmId string) ([]*RealmClientPolicy, error) {
+	var policies realmClientPolicies
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-policies/policies", realmId), &policies, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies.Policies {
+		policy.RealmId = realmId
+	}
+
+	return policies.Policies, nil
+}
+
+func (keycloakClient *KeycloakClient) updateRealmClientPolicies(ctx context.Context, realmId string, policies []*RealmClientPolicy) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/client-policies/policies", realmId), &realmClientPolicies{
+		Policies: policies,
+	})
+}
+
+func (keycloakClient *KeycloakClient) validateRealmClientPolicy(ctx context.Context, policy *RealmClientPolicy) error {
+	serverInfo, err := keycloakClient.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, condition := range policy.Conditions {
+		if !serverInfo.providerInstalled("client-policy-condition", condition.Condition) {
+			return fmt.Errorf("validation error: client policy condition \"%s\" does not exist on the server, installed providers: %s", condition.Condition, serverInfo.getInstalledProvidersNames("client-policy-condition"))
+		}
+	}
+
+	return nil
+}
+
+// The policies of a realm can only be replaced as a whole, so every change reads them, changes the one policy, and writes them back
+func (keycloakClient *KeycloakClient) NewRealmClientPolicy(ctx context.Context, policy *RealmClientPolicy) error {
+	err := keycloakClient.validateRealmClientPolicy(ctx, policy)
+	if err != nil {
+		return err
+	}
+
+	keycloakClient.clientPoliciesMutex.Lock()
+	defer keycloakClient.clientPoliciesMutex.Unlock()
+
+	policies, err := keycloakClient.getRealmClientPolicies(ctx, policy.RealmId)
+	if err != nil {
+		return err
+	}
+
+	for _, existingPolicy := range policies {
+		if existingPolicy.Name == policy.Name {
+			return fmt.Errorf("client policy %s already exists in realm %s", policy.Name, policy.RealmId)
+		}
+	}
+
+	return keycloakClient.updateRealmClientPolicies(ctx, policy.RealmId, append(policies, policy))
+}
+
+func (keycloakClient *KeycloakClient) GetRealmClientPolicy(ctx context.Context, realmId, name string) (*RealmClientPolicy, error) {
+	policies, err := keycloakClient.getRealmClientPolicies(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies {
+		if policy.Name == name {
+			return policy, nil
+		}
+	}
+
+	return nil, &ApiError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("client policy %s does not exist in realm %s", name, realmId),
+	}
+}
+
+func (keycloakClient *KeycloakClient) UpdateRealmClientPolicy(ctx context.Context, policy *RealmClientPolicy) error {
+	err := keycloakClient.validateRealmClientPolicy(ctx, policy)
+	if err != nil {
+		return err
+	}
+
+	keycloakClient.clientPoliciesMutex.Lock()
+	defer keycloakClient.clientPoliciesMutex.Unlock()
+
+	policies, err := keycloakClient.getRealmClientPolicies(ctx, policy.RealmId)
+	if err != nil {
+		return err
+	}
+
+	for i, existingPolicy := range policies {
+		if existingPolicy.Name == policy.Name {
+			policies[i] = policy
+
+			return keycloakClient.updateRealmClientPolicies(ctx, policy.RealmId, policies)
+		}
+	}
+
+	return &ApiError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("client policy %s does not exist in realm %s", policy.Name, policy.RealmId),
+	}
+}
+
+func (keycloakClient *KeycloakClient) DeleteRealmClientPolicy(ctx context.Context, realmId, name string) error {
+	keycloakClient.clientPoliciesMutex.Lock()
+	defer keycloakClient.clientPoliciesMutex.Unlock()
+
+	policies, err := keycloakClient.getRealmClientPolicies(ctx, realmId)
+	if err != nil {
+		return err
+	}
+
+	remainingPolicies := make([]*RealmClientPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.Name != name {
+			remainingPolicies = append(remainingPolicies, policy)
+		}
+	}
+
+	if len(remainingPolicies) == len(policies) {
+		return nil
+	}
+
+	return keycloakClient.updateRealmClientPolicies(ctx, realmId, remainingPolicies)
+}
@@ -0,0 +1,152 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type RealmClientPolicyProfileExecutor struct {
+	Executor      string                 `json:"executor"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+type RealmClientPolicyProfile struct {
+	RealmId     string                              `json:"-"`
+	Name        string                              `json:"name"`
+	Description string                              `json:"description,omitempty"`
+	Executors   []*RealmClientPolicyProfileExecutor `json:"executors"`
+}
+
+type realmClientPolicyProfiles struct {
+	Profiles []*RealmClientPolicyProfile `json:"profiles"`
+}
+
+func (keycloakClient *KeycloakClient) getRealmClientPolicyProfiles(ctx context.Context, realmId string) ([]*RealmClientPolicyProfile, error) {
+	var profiles realmClientPolicyProfiles
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-policies/profiles", realmId), &profiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profile := range profiles.Profiles {
+		profile.RealmId = realmId
+	}
+
+	return profiles.Profiles, nil
+}
+
+func (keycloakClient *KeycloakClient) updateRealmClientPolicyProfiles(ctx context.Context, realmId string, profiles []*RealmClientPolicyProfile) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/client-policies/profiles", realmId), &realmClientPolicyProfiles{
+		Profiles: profiles,
+	})
+}
+
+func (keycloakClient *KeycloakClient) validateRealmClientPolicyProfile(ctx context.Context, profile *RealmClientPolicyProfile) error {
+	serverInfo, err := keycloakClient.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, executor := range profile.Executors {
+		if !serverInfo.providerInstalled("client-policy-executor", executor.Executor) {
+			return fmt.Errorf("validation error: client policy executor \"%s\" does not exist on the server, installed providers: %s", executor.Executor, serverInfo.getInstalledProvidersNames("client-policy-executor"))
+		}
+	}
+
+	return nil
+}
+
+// The profiles of a realm can only be replaced as a whole, so every change reads them, changes the one profile, and writes them back
+func (keycloakClient *KeycloakClient) NewRealmClientPolicyProfile(ctx context.Context, profile *RealmClientPolicyProfile) error {
+	err := keycloakClient.validateRealmClientPolicyProfile(ctx, profile)
+	if err != nil {
+		return err
+	}
+
+	keycloakClient.clientPoliciesMutex.Lock()
+	defer keycloakClient.clientPoliciesMutex.Unlock()
+
+	profiles, err := keycloakClient.getRealmClientPolicyProfiles(ctx, profile.RealmId)
+	if err != nil {
+		return err
+	}
+
+	for _, existingProfile := range profiles {
+		if existingProfile.Name == profile.Name {
+			return fmt.Errorf("client policy profile %s already exists in realm %s", profile.Name, profile.RealmId)
+		}
+	}
+
+	return keycloakClient.updateRealmClientPolicyProfiles(ctx, profile.RealmId, append(profiles, profile))
+}
+
+func (keycloakClient *KeycloakClient) GetRealmClientPolicyProfile(ctx context.Context, realmId, name string) (*RealmClientPolicyProfile, error) {
+	profiles, err := keycloakClient.getRealmClientPolicyProfiles(ctx, realmId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+
+	return nil, &ApiError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("client policy profile %s does not exist in realm %s", name, realmId),
+	}
+}
+
+func (keycloakClient *KeycloakClient) UpdateRealmClientPolicyProfile(ctx context.Context, profile *RealmClientPolicyProfile) error {
+	err := keycloakClient.validateRealmClientPolicyProfile(ctx, profile)
+	if err != nil {
+		return err
+	}
+
+	keycloakClient.clientPoliciesMutex.Lock()
+	defer keycloakClient.clientPoliciesMutex.Unlock()
+
+	profiles, err := keycloakClient.getRealmClientPolicyProfiles(ctx, profile.RealmId)
+	if err != nil {
+		return err
+	}
+
+	for i, existingProfile := range profiles {
+		if existingProfile.Name == profile.Name {
+			profiles[i] = profile
+
+			return keycloakClient.updateRealmClientPolicyProfiles(ctx, profile.RealmId, profiles)
+		}
+	}
+
+	return &ApiError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("client policy profile %s does not exist in realm %s", profile.Name, profile.RealmId),
+	}
+}
+
+func (keycloakClient *KeycloakClient) DeleteRealmClientPolicyProfile(ctx context.Context, realmId, name string) error {
+	keycloakClient.clientPoliciesMutex.Lock()
+	defer keycloakClient.clientPoliciesMutex.Unlock()
+
+	profiles, err := keycloakClient.getRealmClientPolicyProfiles(ctx, realmId)
+	if err != nil {
+		return err
+	}
+
+	remainingProfiles := make([]*RealmClientPolicyProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		if profile.Name != name {
+			remainingProfiles = append(remainingProfiles, profile)
+		}
+	}
+
+	if len(remainingProfiles) == len(profiles) {
+		return nil
+	}
+
+	return keycloakClient.updateRealmClientPolicyProfiles(ctx, realmId, remainingProfiles)
+}
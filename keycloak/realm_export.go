@@ -0,0 +1,145 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Keys holding IDs that are generated by Keycloak, and which differ between otherwise identical realms
+var realmExportIdKeys = map[string]bool{
+	"id":          true,
+	"containerId": true,
+	"internalId":  true,
+}
+
+// Keys holding lists of entities, which Keycloak does not always export in the same order. Lists nested within objects
+// under these keys, such as the realm and client roles under roles, are sorted as well.
+var realmExportEntityKeys = map[string]bool{
+	"clients":                 true,
+	"clientScopes":            true,
+	"users":                   true,
+	"groups":                  true,
+	"subGroups":               true,
+	"roles":                   true,
+	"protocolMappers":         true,
+	"identityProviders":       true,
+	"identityProviderMappers": true,
+	"components":              true,
+	"subComponents":           true,
+	"authenticationFlows":     true,
+	"authenticatorConfig":     true,
+	"requiredActions":         true,
+	"authorizationSettings":   true,
+}
+
+// The keys identifying an entity, in the order they are compared in
+var realmExportEntityNameKeys = []string{"identityProviderAlias", "clientId", "alias", "username", "name"}
+
+// GetRealmExport exports a realm as JSON. Secrets, such as client secrets and LDAP bind credentials, are masked by Keycloak.
+// When stripIds is true, the IDs generated by Keycloak are removed, so exports of different environments can be compared.
+func (keycloakClient *KeycloakClient) GetRealmExport(ctx context.Context, realmId string, exportClients, exportGroupsAndRoles, stripIds bool) (string, error) {
+	params := url.Values{}
+	params.Set("exportClients", strconv.FormatBool(exportClients))
+	params.Set("exportGroupsAndRoles", strconv.FormatBool(exportGroupsAndRoles))
+
+	body, err := keycloakClient.sendRaw(ctx, fmt.Sprintf("/realms/%s/partial-export?%s", realmId, params.Encode()), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return normalizeRealmExport(body, stripIds)
+}
+
+// normalizeRealmExport re-encodes an export with its keys and known lists of entities sorted, so the same realm always
+// results in the same JSON
+func normalizeRealmExport(export []byte, stripIds bool) (string, error) {
+	var realm interface{}
+
+	err := json.Unmarshal(export, &realm)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse realm export: %v", err)
+	}
+
+	if stripIds {
+		realm = stripJsonKeys(realm, realmExportIdKeys)
+	}
+
+	realm = sortRealmExportEntities(realm, false)
+
+	normalized, err := json.Marshal(realm)
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+// sortRealmExportEntities recursively sorts the lists of entities within a decoded export by their name. Other lists,
+// such as the executions of an authentication flow, are ordered by Keycloak and left alone.
+func sortRealmExportEntities(value interface{}, entities bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = sortRealmExportEntities(child, entities || realmExportEntityKeys[key])
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = sortRealmExportEntities(child, false)
+		}
+
+		if entities {
+			sort.SliceStable(v, func(i, j int) bool {
+				return realmExportEntityName(v[i]) < realmExportEntityName(v[j])
+			})
+		}
+	}
+
+	return value
+}
+
+func realmExportEntityName(entity interface{}) string {
+	switch e := entity.(type) {
+	case string:
+		return e
+	case map[string]interface{}:
+		var names []string
+		for _, key := range realmExportEntityNameKeys {
+			if name, ok := e[key].(string); ok {
+				names = append(names, name)
+			}
+		}
+
+		return strings.Join(names, "/")
+	}
+
+	return ""
+}
+
+// stripJsonKeys recursively removes the given keys from a decoded JSON value
+func stripJsonKeys(value interface{}, keys map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		stripped := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if !keys[key] {
+				stripped[key] = stripJsonKeys(child, keys)
+			}
+		}
+
+		return stripped
+	case []interface{}:
+		stripped := make([]interface{}, len(v))
+		for i, child := range v {
+			stripped[i] = stripJsonKeys(child, keys)
+		}
+
+		return stripped
+	default:
+		return v
+	}
+}
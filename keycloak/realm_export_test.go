@@ -0,0 +1,83 @@
+package keycloak
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRealmExport(t *testing.T) {
+	first := `{"realm": "test", "id": "1f2e", "clients": [{"id": "abc", "clientId": "web", "secret": "**********"}], "enabled": true}`
+	second := `{"enabled": true, "clients": [{"secret": "**********", "clientId": "web", "id": "abc"}], "id": "1f2e", "realm": "test"}`
+
+	firstNormalized, err := normalizeRealmExport([]byte(first), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondNormalized, err := normalizeRealmExport([]byte(second), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if firstNormalized != secondNormalized {
+		t.Fatalf("expected exports with different key order to be normalized to the same JSON, got %s and %s", firstNormalized, secondNormalized)
+	}
+}
+
+func TestNormalizeRealmExport_stripIds(t *testing.T) {
+	export := `{"realm": "test", "id": "1f2e", "roles": {"realm": [{"id": "r1", "name": "admin", "containerId": "1f2e"}]}, "identityProviders": [{"alias": "google", "internalId": "i1"}]}`
+
+	normalized, err := normalizeRealmExport([]byte(export), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"identityProviders":[{"alias":"google"}],"realm":"test","roles":{"realm":[{"name":"admin"}]}}`
+	if normalized != expected {
+		t.Fatalf("expected IDs to be stripped, got %s", normalized)
+	}
+}
+
+func TestNormalizeRealmExport_sortEntities(t *testing.T) {
+	first := `{
+		"clients": [
+			{"clientId": "web", "protocolMappers": [{"name": "b"}, {"name": "a"}]},
+			{"clientId": "api"}
+		],
+		"roles": {"realm": [{"name": "user"}, {"name": "admin"}], "client": {"web": [{"name": "viewer"}, {"name": "editor"}]}},
+		"groups": [{"name": "b", "subGroups": [{"name": "y"}, {"name": "x"}]}, {"name": "a"}],
+		"identityProviderMappers": [{"identityProviderAlias": "google", "name": "m"}, {"identityProviderAlias": "github", "name": "m"}],
+		"components": {"org.keycloak.keys.KeyProvider": [{"name": "rsa"}, {"name": "hmac"}]},
+		"authenticationFlows": [{"alias": "browser", "authenticationExecutions": [{"authenticator": "cookie"}, {"authenticator": "auth-otp-form"}]}]
+	}`
+	second := `{
+		"clients": [
+			{"clientId": "api"},
+			{"clientId": "web", "protocolMappers": [{"name": "a"}, {"name": "b"}]}
+		],
+		"roles": {"realm": [{"name": "admin"}, {"name": "user"}], "client": {"web": [{"name": "editor"}, {"name": "viewer"}]}},
+		"groups": [{"name": "a"}, {"name": "b", "subGroups": [{"name": "x"}, {"name": "y"}]}],
+		"identityProviderMappers": [{"identityProviderAlias": "github", "name": "m"}, {"identityProviderAlias": "google", "name": "m"}],
+		"components": {"org.keycloak.keys.KeyProvider": [{"name": "hmac"}, {"name": "rsa"}]},
+		"authenticationFlows": [{"alias": "browser", "authenticationExecutions": [{"authenticator": "cookie"}, {"authenticator": "auth-otp-form"}]}]
+	}`
+
+	firstNormalized, err := normalizeRealmExport([]byte(first), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondNormalized, err := normalizeRealmExport([]byte(second), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if firstNormalized != secondNormalized {
+		t.Fatalf("expected exports with entities in a different order to be normalized to the same JSON, got %s and %s", firstNormalized, secondNormalized)
+	}
+
+	expectedFlow := `"authenticationExecutions":[{"authenticator":"cookie"},{"authenticator":"auth-otp-form"}]`
+	if !strings.Contains(firstNormalized, expectedFlow) {
+		t.Fatalf("expected the order of authentication executions to be kept, got %s", firstNormalized)
+	}
+}
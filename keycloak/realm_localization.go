@@ -0,0 +1,93 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type themeMessage struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// GetRealmLocalizationTexts returns the texts that a realm overrides for a locale. When useRealmDefaultLocaleFallback
+// is true, texts that are only overridden for the default locale of the realm are included as well.
+func (keycloakClient *KeycloakClient) GetRealmLocalizationTexts(ctx context.Context, realmId, locale string, useRealmDefaultLocaleFallback bool) (map[string]string, error) {
+	var texts map[string]string
+	var params map[string]string
+
+	if useRealmDefaultLocaleFallback {
+		params = map[string]string{
+			"useRealmDefaultLocaleFallback": "true",
+		}
+	}
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, url.PathEscape(locale)), &texts, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if texts == nil {
+		texts = map[string]string{}
+	}
+
+	return texts, nil
+}
+
+// GetRealmEffectiveMessages returns the messages a realm shows for a locale: the messages of the given theme, or of the
+// realm's theme when it is empty, with the texts overridden by the realm on top. Unlike the rest of the API, these are
+// served outside of the admin API, under /resources.
+func (keycloakClient *KeycloakClient) GetRealmEffectiveMessages(ctx context.Context, realmId, themeType, theme, locale string) (map[string]string, error) {
+	resourceUrl := fmt.Sprintf("%s/resources/%s/%s/%s", keycloakClient.baseUrl, url.PathEscape(realmId), url.PathEscape(themeType), url.PathEscape(locale))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, resourceUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if theme != "" {
+		request.URL.RawQuery = url.Values{"theme": []string{theme}}.Encode()
+	}
+
+	body, _, err := keycloakClient.sendRequest(ctx, request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var themeMessages []themeMessage
+
+	err = json.Unmarshal(body, &themeMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse messages: %v", err)
+	}
+
+	messages := make(map[string]string, len(themeMessages))
+	for _, message := range themeMessages {
+		messages[message.Key] = message.Value
+	}
+
+	return messages, nil
+}
+
+// UpdateRealmLocalizationTexts creates or updates the given texts of a locale, leaving any other texts of the locale alone
+func (keycloakClient *KeycloakClient) UpdateRealmLocalizationTexts(ctx context.Context, realmId, locale string, texts map[string]string) error {
+	if len(texts) == 0 {
+		return nil
+	}
+
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, url.PathEscape(locale)), texts)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) DeleteRealmLocalizationText(ctx context.Context, realmId, locale, key string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/localization/%s/%s", realmId, url.PathEscape(locale), url.PathEscape(key)), nil)
+}
+
+// DeleteRealmLocalizationTexts deletes every text that a realm overrides for a locale
+func (keycloakClient *KeycloakClient) DeleteRealmLocalizationTexts(ctx context.Context, realmId, locale string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, url.PathEscape(locale)), nil)
+}
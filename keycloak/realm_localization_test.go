@@ -0,0 +1,41 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRealmEffectiveMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/resources/test/login/de" || r.URL.Query().Get("theme") != "custom" {
+			t.Errorf("unexpected request for %s", r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"key": "doLogIn", "value": "Anmelden"}, {"key": "loginTitle", "value": "Bei Test anmelden"}]`))
+	}))
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages, err := keycloakClient.GetRealmEffectiveMessages(context.Background(), "test", "login", "custom", "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 2 || messages["doLogIn"] != "Anmelden" || messages["loginTitle"] != "Bei Test anmelden" {
+		t.Fatalf("unexpected messages %v", messages)
+	}
+}
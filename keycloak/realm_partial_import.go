@@ -0,0 +1,137 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	PartialImportActionAdded       = "ADDED"
+	PartialImportActionSkipped     = "SKIPPED"
+	PartialImportActionOverwritten = "OVERWRITTEN"
+
+	partialImportResourceTypeUser             = "USER"
+	partialImportResourceTypeGroup            = "GROUP"
+	partialImportResourceTypeClient           = "CLIENT"
+	partialImportResourceTypeIdentityProvider = "IDP"
+	partialImportResourceTypeRealmRole        = "REALM_ROLE"
+	partialImportResourceTypeClientRole       = "CLIENT_ROLE"
+)
+
+var PartialImportIfResourceExistsPolicies = []string{"FAIL", "SKIP", "OVERWRITE"}
+
+type PartialImport struct {
+	RealmId          string
+	IfResourceExists string
+	// A realm representation, such as the file written by a realm export
+	RealmJson string
+}
+
+// PartialImportRepresentation holds the sections of a realm representation that can be imported into an existing realm.
+// Every other section of a realm export, such as the realm's own settings, is left out of the import. The sections are
+// sent to Keycloak unchanged, so every attribute they hold is imported.
+type PartialImportRepresentation struct {
+	IfResourceExists  string          `json:"ifResourceExists"`
+	Users             json.RawMessage `json:"users,omitempty"`
+	Groups            json.RawMessage `json:"groups,omitempty"`
+	Clients           json.RawMessage `json:"clients,omitempty"`
+	IdentityProviders json.RawMessage `json:"identityProviders,omitempty"`
+	Roles             json.RawMessage `json:"roles,omitempty"`
+}
+
+type PartialImportResult struct {
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	Id           string `json:"id"`
+}
+
+type PartialImportResults struct {
+	Added       int                    `json:"added"`
+	Skipped     int                    `json:"skipped"`
+	Overwritten int                    `json:"overwritten"`
+	Results     []*PartialImportResult `json:"results"`
+}
+
+// partialImportDeleteOrder is the order imported resources are deleted in. Users and groups go first, since they may
+// be mapped to roles, and client roles go before the clients that own them.
+var partialImportDeleteOrder = []string{
+	partialImportResourceTypeUser,
+	partialImportResourceTypeGroup,
+	partialImportResourceTypeClientRole,
+	partialImportResourceTypeRealmRole,
+	partialImportResourceTypeClient,
+	partialImportResourceTypeIdentityProvider,
+}
+
+func (partialImport *PartialImport) representation() (*PartialImportRepresentation, error) {
+	var representation PartialImportRepresentation
+
+	err := json.Unmarshal([]byte(partialImport.RealmJson), &representation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse realm representation: %v", err)
+	}
+
+	representation.IfResourceExists = partialImport.IfResourceExists
+
+	return &representation, nil
+}
+
+func (keycloakClient *KeycloakClient) NewPartialImport(ctx context.Context, partialImport *PartialImport) (*PartialImportResults, error) {
+	representation, err := partialImport.representation()
+	if err != nil {
+		return nil, err
+	}
+
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/partialImport", partialImport.RealmId), representation)
+	if err != nil {
+		return nil, err
+	}
+
+	var results PartialImportResults
+
+	err = json.Unmarshal(body, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return &results, nil
+}
+
+// DeletePartialImportResults deletes every resource that was added by a partial import. Resources that existed before
+// the import, and were either skipped or overwritten, are left alone. Resources that no longer exist are ignored.
+func (keycloakClient *KeycloakClient) DeletePartialImportResults(ctx context.Context, realmId string, results []*PartialImportResult) error {
+	for _, resourceType := range partialImportDeleteOrder {
+		for _, result := range results {
+			if result.ResourceType != resourceType || result.Action != PartialImportActionAdded {
+				continue
+			}
+
+			err := keycloakClient.deletePartialImportResult(ctx, realmId, result)
+			if err != nil && !ErrorIs404(err) {
+				return fmt.Errorf("failed to delete imported %s %s: %v", result.ResourceType, result.ResourceName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) deletePartialImportResult(ctx context.Context, realmId string, result *PartialImportResult) error {
+	switch result.ResourceType {
+	case partialImportResourceTypeUser:
+		return keycloakClient.DeleteUser(ctx, realmId, result.Id)
+	case partialImportResourceTypeGroup:
+		return keycloakClient.DeleteGroup(ctx, realmId, result.Id)
+	case partialImportResourceTypeRealmRole, partialImportResourceTypeClientRole:
+		return keycloakClient.DeleteRole(ctx, realmId, result.Id)
+	case partialImportResourceTypeClient:
+		// clients of every protocol are deleted the same way
+		return keycloakClient.DeleteOpenidClient(ctx, realmId, result.Id)
+	case partialImportResourceTypeIdentityProvider:
+		return keycloakClient.DeleteIdentityProvider(ctx, realmId, result.ResourceName)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewPartialImport_sectionsSentUnchanged(t *testing.T) {
+	users := `[{"username":"alice","enabled":true,"credentials":[{"type":"password","value":"secret"}],"realmRoles":["admin"],"groups":["/parent"]}]`
+	groups := `[{"name":"parent","path":"/parent","realmRoles":["admin"],"subGroups":[{"name":"child","path":"/parent/child"}]}]`
+	roles := `{"realm":[{"name":"admin","composite":true,"composites":{"realm":["user"]}}],"client":{"web":[{"name":"viewer"}]}}`
+	clients := `[{"clientId":"web","protocol":"openid-connect","protocolMappers":[{"name":"email","protocol":"openid-connect","protocolMapper":"oidc-usermodel-property-mapper"}],"defaultClientScopes":["email"],"optionalClientScopes":["phone"],"authorizationSettings":{"resources":[{"name":"documents"}]}},{"clientId":"sp","protocol":"saml"}]`
+	identityProviders := `[{"alias":"google","providerId":"google","updateProfileFirstLoginMode":"on","config":{"clientId":"id"}}]`
+
+	realmJson := `{"realm":"source","enabled":true,"smtpServer":{"host":"mail"},"users":` + users + `,"groups":` + groups + `,"roles":` + roles + `,"clients":` + clients + `,"identityProviders":` + identityProviders + `}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/realms/test/partialImport" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		var payload map[string]interface{}
+		err = json.Unmarshal(body, &payload)
+		if err != nil {
+			t.Error(err)
+		}
+
+		expected := map[string]string{
+			"users":             users,
+			"groups":            groups,
+			"roles":             roles,
+			"clients":           clients,
+			"identityProviders": identityProviders,
+		}
+
+		for section, value := range expected {
+			var expectedValue interface{}
+			json.Unmarshal([]byte(value), &expectedValue)
+
+			if !reflect.DeepEqual(payload[section], expectedValue) {
+				t.Errorf("expected section %s to be sent unchanged, got %v", section, payload[section])
+			}
+		}
+
+		if payload["ifResourceExists"] != "SKIP" {
+			t.Errorf("expected ifResourceExists to be SKIP, got %v", payload["ifResourceExists"])
+		}
+
+		if _, ok := payload["smtpServer"]; ok {
+			t.Errorf("expected realm settings to be left out of the import")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"added":1,"skipped":0,"overwritten":0,"results":[{"action":"ADDED","resourceType":"USER","resourceName":"alice","id":"u1"}]}`))
+	}))
+	defer server.Close()
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		Realm:         "master",
+		ClientTimeout: 5,
+		ExternalToken: &ExternalToken{AccessToken: "token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := keycloakClient.NewPartialImport(context.Background(), &PartialImport{
+		RealmId:          "test",
+		IfResourceExists: "SKIP",
+		RealmJson:        realmJson,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if results.Added != 1 || len(results.Results) != 1 || results.Results[0].Id != "u1" {
+		t.Fatalf("unexpected results %+v", results)
+	}
+}
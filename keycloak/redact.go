@@ -0,0 +1,140 @@
+package keycloak
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Everything logged by the client passes through these functions first, so credentials never end up in debug logs
+
+const redactedValue = "**REDACTED**"
+
+// Form fields sent to the token endpoint
+var sensitiveFormFields = []string{
+	"password",
+	"client_secret",
+	"client_assertion",
+	"assertion",
+	"subject_token",
+	"refresh_token",
+	"access_token",
+	"token",
+}
+
+// JSON keys of Keycloak representations, compared case-insensitively. Keys of component configs, such as the
+// bindCredential of an LDAP user federation, are matched the same way.
+var sensitiveJsonKeys = map[string]bool{
+	"access_token":            true,
+	"refresh_token":           true,
+	"id_token":                true,
+	"client_secret":           true,
+	"secret":                  true,
+	"clientsecret":            true,
+	"password":                true,
+	"bindcredential":          true,
+	"privatekey":              true,
+	"keystorepassword":        true,
+	"keypassword":             true,
+	"registrationaccesstoken": true,
+	"secretdata":              true,
+	"credentialdata":          true,
+	// SAML client attributes
+	"saml.signing.private.key":    true,
+	"saml.encryption.private.key": true,
+}
+
+// Credential representations, such as {"type": "password", "value": "..."}, carry their secret in an otherwise innocuous key
+var sensitiveCredentialTypes = map[string]bool{
+	"password": true,
+	"secret":   true,
+	"otp":      true,
+	"totp":     true,
+	"hotp":     true,
+}
+
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+func redactFormData(formData url.Values) string {
+	redacted := url.Values{}
+	for key, values := range formData {
+		redacted[key] = values
+	}
+
+	for _, field := range sensitiveFormFields {
+		if _, ok := redacted[field]; ok {
+			redacted.Set(field, redactedValue)
+		}
+	}
+
+	return redacted.Encode()
+}
+
+// redactBody masks sensitive values within a JSON body. Bodies in any other format, such as the XML of SAML client
+// installation providers which may include private keys, are dropped entirely.
+func redactBody(body []byte) string {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		redacted, err := json.Marshal(redactJsonValue(decoded))
+		if err == nil {
+			return string(redacted)
+		}
+	}
+
+	return fmt.Sprintf("%s (%d bytes)", redactedValue, len(body))
+}
+
+func redactJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if sensitiveJsonKeys[strings.ToLower(key)] {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redactJsonValue(child)
+			}
+		}
+
+		if credentialType, ok := v["type"].(string); ok && sensitiveCredentialTypes[strings.ToLower(credentialType)] {
+			if _, ok := v["value"]; ok {
+				redacted["value"] = redactedValue
+			}
+		}
+
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, child := range v {
+			redacted[i] = redactJsonValue(child)
+		}
+
+		return redacted
+	default:
+		return v
+	}
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for header, values := range headers {
+		redacted[header] = strings.Join(values, ", ")
+	}
+
+	// additional headers set by the user may not be in canonical form
+	for header := range redacted {
+		for _, sensitiveHeader := range sensitiveHeaders {
+			if strings.EqualFold(header, sensitiveHeader) {
+				redacted[header] = redactedValue
+			}
+		}
+	}
+
+	return redacted
+}
@@ -0,0 +1,156 @@
+package keycloak
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testSecret = "s3cr3t-value"
+
+func assertRedacted(t *testing.T, description, redacted string) {
+	t.Helper()
+
+	if strings.Contains(redacted, testSecret) {
+		t.Errorf("%s was not redacted: %s", description, redacted)
+	}
+
+	if !strings.Contains(redacted, redactedValue) {
+		t.Errorf("%s should mention that a value was redacted: %s", description, redacted)
+	}
+}
+
+func TestRedactFormData(t *testing.T) {
+	for _, field := range sensitiveFormFields {
+		formData := url.Values{}
+		formData.Set("client_id", "terraform")
+		formData.Set("grant_type", "password")
+		formData.Set(field, testSecret)
+
+		redacted, err := url.ParseQuery(redactFormData(formData))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if redacted.Get(field) != redactedValue {
+			t.Errorf("form field %s was not redacted: %v", field, redacted)
+		}
+
+		if redacted.Get("client_id") != "terraform" {
+			t.Errorf("expected non-sensitive form fields to be kept: %v", redacted)
+		}
+
+		if formData.Get(field) != testSecret {
+			t.Errorf("expected original form data to be left untouched")
+		}
+	}
+}
+
+func TestRedactBody_sensitiveKeys(t *testing.T) {
+	bodies := map[string]string{
+		"client secret":            `{"clientId": "terraform", "secret": "` + testSecret + `"}`,
+		"identity provider secret": `{"alias": "google", "config": {"clientSecret": "` + testSecret + `"}}`,
+		"ldap bind credential":     `{"providerId": "ldap", "config": {"bindCredential": ["` + testSecret + `"]}}`,
+		"smtp password":            `{"realm": "test", "smtpServer": {"host": "smtp", "password": "` + testSecret + `"}}`,
+		"keystore private key":     `{"providerId": "rsa", "config": {"privateKey": ["` + testSecret + `"]}}`,
+		"keystore password":        `{"providerId": "java-keystore", "config": {"keystorePassword": ["` + testSecret + `"]}}`,
+		"keystore key password":    `{"providerId": "java-keystore", "config": {"keyPassword": ["` + testSecret + `"]}}`,
+		"registration token":       `{"clientId": "terraform", "registrationAccessToken": "` + testSecret + `"}`,
+		"token response":           `{"access_token": "` + testSecret + `", "refresh_token": "` + testSecret + `", "id_token": "` + testSecret + `", "token_type": "Bearer"}`,
+		"stored credential":        `[{"type": "otp", "secretData": "` + testSecret + `", "credentialData": "` + testSecret + `"}]`,
+		"nested in list":           `[{"components": [{"config": {"BindCredential": ["` + testSecret + `"]}}]}]`,
+	}
+
+	for description, body := range bodies {
+		assertRedacted(t, description, redactBody([]byte(body)))
+	}
+}
+
+func TestRedactBody_samlClient(t *testing.T) {
+	client := &SamlClient{
+		ClientId: "saml-client",
+		Attributes: &SamlClientAttributes{
+			SigningCertificate: "MIICertificate",
+			SigningPrivateKey:  testSecret,
+		},
+	}
+
+	body, err := json.Marshal(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redacted := redactBody(body)
+	assertRedacted(t, "saml signing private key", redacted)
+
+	if !strings.Contains(redacted, "MIICertificate") {
+		t.Errorf("expected the signing certificate to be kept: %s", redacted)
+	}
+
+	attributes := `{"clientId": "saml-client", "attributes": {"saml.encryption.private.key": "` + testSecret + `"}}`
+	assertRedacted(t, "saml encryption private key", redactBody([]byte(attributes)))
+}
+
+func TestRedactBody_credentialRepresentations(t *testing.T) {
+	bodies := map[string]string{
+		"password reset":   `{"type": "password", "value": "` + testSecret + `", "temporary": false}`,
+		"client secret":    `{"type": "secret", "value": "` + testSecret + `"}`,
+		"user credentials": `{"username": "bob", "credentials": [{"type": "password", "value": "` + testSecret + `"}]}`,
+	}
+
+	for description, body := range bodies {
+		assertRedacted(t, description, redactBody([]byte(body)))
+	}
+}
+
+func TestRedactBody_keepsOtherValues(t *testing.T) {
+	body := `{"name": "role", "attributes": {"value": ["kept"]}, "secretSize": 32, "type": "string", "value": "kept"}`
+
+	var redacted map[string]interface{}
+	if err := json.Unmarshal([]byte(redactBody([]byte(body))), &redacted); err != nil {
+		t.Fatal(err)
+	}
+
+	var original map[string]interface{}
+	json.Unmarshal([]byte(body), &original)
+
+	originalJson, _ := json.Marshal(original)
+	redactedJson, _ := json.Marshal(redacted)
+
+	if string(originalJson) != string(redactedJson) {
+		t.Fatalf("expected body without secrets to be unchanged, got %s", redactedJson)
+	}
+}
+
+func TestRedactBody_nonJson(t *testing.T) {
+	body := `<keycloak-saml-adapter><PrivateKeyPem>` + testSecret + `</PrivateKeyPem></keycloak-saml-adapter>`
+
+	assertRedacted(t, "xml body", redactBody([]byte(body)))
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+testSecret)
+	headers.Set("Cookie", "KEYCLOAK_SESSION="+testSecret)
+	headers.Set("Accept", "application/json")
+	headers["x-lowercase-authorization"] = []string{"kept"}
+	headers["authorization"] = []string{"Basic " + testSecret}
+
+	redacted := redactHeaders(headers)
+
+	for header, value := range redacted {
+		if strings.Contains(value, testSecret) {
+			t.Errorf("header %s was not redacted: %s", header, value)
+		}
+	}
+
+	if redacted["Accept"] != "application/json" || redacted["x-lowercase-authorization"] != "kept" {
+		t.Errorf("expected non-sensitive headers to be kept: %v", redacted)
+	}
+
+	if headers.Get("Authorization") != "Bearer "+testSecret {
+		t.Errorf("expected original headers to be left untouched")
+	}
+}
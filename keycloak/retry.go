@@ -0,0 +1,174 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy controls how requests to the Keycloak API are retried when they fail with a transient error
+type RetryPolicy struct {
+	MaxRetries  int
+	WaitMin     time.Duration
+	WaitMax     time.Duration
+	StatusCodes []int
+	// Only requests using one of these methods are retried. Non-idempotent methods such as POST must be opted into explicitly.
+	Methods []string
+}
+
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+var DefaultRetryMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:  1,
+		WaitMin:     time.Second * 1,
+		WaitMax:     time.Second * 3,
+		StatusCodes: DefaultRetryStatusCodes,
+		Methods:     DefaultRetryMethods,
+	}
+}
+
+func (policy *RetryPolicy) retriesMethod(method string) bool {
+	for _, m := range policy.Methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (policy *RetryPolicy) retriesStatusCode(statusCode int) bool {
+	for _, code := range policy.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (policy *RetryPolicy) checkRetry(ctx context.Context, response *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	// connection errors are retried unless they are known to be unrecoverable, such as TLS verification failures
+	if err != nil {
+		return retryablehttp.DefaultRetryPolicy(ctx, response, err)
+	}
+
+	return policy.retriesStatusCode(response.StatusCode), nil
+}
+
+// backoff waits exponentially longer between attempts, unless the server asked for a specific delay with Retry-After.
+// The requested delay is kept between min and max, so a proxy asking for a long delay doesn't stall a plan.
+func (policy *RetryPolicy) backoff(min, max time.Duration, attemptNum int, response *http.Response) time.Duration {
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now()); ok {
+			if retryAfter < min {
+				return min
+			}
+
+			if retryAfter > max {
+				return max
+			}
+
+			return retryAfter
+		}
+	}
+
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+}
+
+// Retry-After may either be a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// retryRoundTripper only sends requests through the retrying client when their method is covered by the retry policy
+type retryRoundTripper struct {
+	policy   *RetryPolicy
+	retrying http.RoundTripper
+	direct   *http.Client
+}
+
+func newRetryRoundTripper(policy *RetryPolicy, client *http.Client) *retryRoundTripper {
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = client
+	retryClient.Logger = nil
+	retryClient.RetryMax = policy.MaxRetries
+	retryClient.RetryWaitMin = policy.WaitMin
+	retryClient.RetryWaitMax = policy.WaitMax
+	retryClient.CheckRetry = policy.checkRetry
+	retryClient.Backoff = policy.backoff
+	// hand the last response back to the caller so it can be turned into an ApiError
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, request *http.Request, attempt int) {
+		if attempt > 0 {
+			tflog.Debug(request.Context(), "Retrying request", map[string]interface{}{
+				"method":  request.Method,
+				"path":    request.URL.Path,
+				"attempt": attempt,
+			})
+		}
+	}
+
+	return &retryRoundTripper{
+		policy:   policy,
+		retrying: &retryablehttp.RoundTripper{Client: retryClient},
+		direct:   client,
+	}
+}
+
+func (roundTripper *retryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if roundTripper.policy.MaxRetries > 0 && roundTripper.policy.retriesMethod(request.Method) {
+		return roundTripper.retrying.RoundTrip(request)
+	}
+
+	response, err := roundTripper.direct.Do(request)
+	// the outer client wraps errors in a url.Error of its own, so avoid nesting them
+	if urlErr, ok := err.(*url.Error); ok {
+		return response, urlErr.Err
+	}
+
+	return response, err
+}
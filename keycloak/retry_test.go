@@ -0,0 +1,142 @@
+package keycloak
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]time.Duration{
+		"5":                             5 * time.Second,
+		"0":                             0,
+		"Mon, 01 Jan 2024 12:00:30 GMT": 30 * time.Second,
+		"Mon, 01 Jan 2024 11:00:00 GMT": 0,
+	}
+
+	for value, expected := range cases {
+		actual, ok := parseRetryAfter(value, now)
+		if !ok {
+			t.Fatalf("expected %q to be parsed", value)
+		}
+		if actual != expected {
+			t.Fatalf("expected %q to parse to %s, got %s", value, expected, actual)
+		}
+	}
+
+	for _, value := range []string{"", "-1", "soon"} {
+		if _, ok := parseRetryAfter(value, now); ok {
+			t.Fatalf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff_retryAfter(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	cases := map[string]time.Duration{
+		"0":    time.Second,
+		"5":    5 * time.Second,
+		"3600": 30 * time.Second,
+	}
+
+	for value, expected := range cases {
+		response := &http.Response{Header: http.Header{"Retry-After": []string{value}}}
+
+		actual := policy.backoff(time.Second, 30*time.Second, 1, response)
+		if actual != expected {
+			t.Fatalf("expected Retry-After %q to wait %s, got %s", value, expected, actual)
+		}
+	}
+}
+
+func newFlakyServer(failures int32, statusCode int) (*httptest.Server, *int32) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(statusCode)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	return server, &requests
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxRetries = 3
+	policy.WaitMin = time.Millisecond
+	policy.WaitMax = time.Millisecond
+
+	return policy
+}
+
+func TestRetryRoundTripper_retriesIdempotentRequests(t *testing.T) {
+	server, requests := newFlakyServer(2, http.StatusTooManyRequests)
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", testRetryPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("{}"))
+	response, err := httpClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK || *requests != 3 {
+		t.Fatalf("expected success after 3 requests, got %d after %d requests", response.StatusCode, *requests)
+	}
+}
+
+func TestRetryRoundTripper_doesNotRetryPostByDefault(t *testing.T) {
+	server, requests := newFlakyServer(1, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", testRetryPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := httpClient.Post(server.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusServiceUnavailable || *requests != 1 {
+		t.Fatalf("expected a single failed request, got %d after %d requests", response.StatusCode, *requests)
+	}
+}
+
+func TestRetryRoundTripper_returnsLastResponseWhenRetriesAreExhausted(t *testing.T) {
+	server, requests := newFlakyServer(10, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", testRetryPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusServiceUnavailable || *requests != 4 {
+		t.Fatalf("expected the last 503 after 4 requests, got %d after %d requests", response.StatusCode, *requests)
+	}
+}
@@ -66,9 +66,7 @@ func (keycloakClient *KeycloakClient) CreateRole(ctx context.Context, role *Role
 }
 
 func (keycloakClient *KeycloakClient) GetRealmRoles(ctx context.Context, realmId string) ([]*Role, error) {
-	var roles []*Role
-
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/roles", realmId), &roles, nil)
+	roles, err := getPaginated[*Role](ctx, keycloakClient, fmt.Sprintf("/realms/%s/roles", realmId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,85 @@
+package keycloak
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	grantTypeJwtBearer     = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	tokenTypeJwt           = "urn:ietf:params:oauth:token-type:jwt"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+var SubjectTokenGrantTypes = []string{"token-exchange", "jwt-bearer"}
+
+// SubjectToken configures login with a JWT issued by an external identity provider, such as the OIDC ID token a CI
+// platform issues to each job. The JWT is exchanged for a Keycloak access token, so no static credentials are needed.
+type SubjectToken struct {
+	// Exactly one of Token, File or EnvVar provides the JWT. Files and environment variables are read again on every
+	// login, so tokens that are rotated while Terraform runs keep working.
+	Token  string
+	File   string
+	EnvVar string
+	// The alias of the identity provider in Keycloak that issued the JWT
+	Issuer string
+	// Either "token-exchange" (the default) or "jwt-bearer"
+	GrantType string
+}
+
+func (subjectToken *SubjectToken) grantType() string {
+	if subjectToken.GrantType == "jwt-bearer" {
+		return grantTypeJwtBearer
+	}
+
+	return grantTypeTokenExchange
+}
+
+func (subjectToken *SubjectToken) read() (string, error) {
+	var token string
+
+	if subjectToken.File != "" {
+		contents, err := os.ReadFile(subjectToken.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read subject token: %v", err)
+		}
+		token = string(contents)
+	} else if subjectToken.EnvVar != "" {
+		token = os.Getenv(subjectToken.EnvVar)
+	} else {
+		token = subjectToken.Token
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("subject token is empty")
+	}
+
+	return token, nil
+}
+
+func (subjectToken *SubjectToken) addFormData(formData url.Values) error {
+	token, err := subjectToken.read()
+	if err != nil {
+		return err
+	}
+
+	if subjectToken.grantType() == grantTypeJwtBearer {
+		formData.Set("assertion", token)
+
+		return nil
+	}
+
+	formData.Set("subject_token", token)
+	formData.Set("subject_token_type", tokenTypeJwt)
+	formData.Set("requested_token_type", tokenTypeAccessToken)
+
+	if subjectToken.Issuer != "" {
+		formData.Set("subject_issuer", subjectToken.Issuer)
+	}
+
+	return nil
+}
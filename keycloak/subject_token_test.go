@@ -0,0 +1,119 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newSubjectTokenEndpoint(t *testing.T, check func(form url.Values)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %s", err)
+		}
+
+		check(r.PostForm)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+	}))
+}
+
+func TestSubjectToken_tokenExchange(t *testing.T) {
+	var subjectTokens []string
+
+	server := newSubjectTokenEndpoint(t, func(form url.Values) {
+		if form.Get("grant_type") != grantTypeTokenExchange || form.Get("subject_token_type") != tokenTypeJwt || form.Get("subject_issuer") != "ci" || form.Get("client_secret") != "secret" {
+			t.Errorf("unexpected token exchange request: %v", form)
+		}
+
+		subjectTokens = append(subjectTokens, form.Get("subject_token"))
+	})
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("first-jwt\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	subjectToken := &SubjectToken{
+		File:   tokenFile,
+		Issuer: "ci",
+	}
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		ClientId:      "terraform",
+		ClientSecret:  "secret",
+		Realm:         "master",
+		ClientTimeout: 5,
+		SubjectToken:  subjectToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := keycloakClient.tokenManager.token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the file is read again on the next login, picking up the rotated token
+	if err := os.WriteFile(tokenFile, []byte("second-jwt"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	keycloakClient.tokenManager.invalidate(token)
+	if _, err := keycloakClient.tokenManager.token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(subjectTokens) != 2 || subjectTokens[0] != "first-jwt" || subjectTokens[1] != "second-jwt" {
+		t.Fatalf("expected the subject token to be re-read on every login, got %v", subjectTokens)
+	}
+}
+
+func TestSubjectToken_jwtBearer(t *testing.T) {
+	server := newSubjectTokenEndpoint(t, func(form url.Values) {
+		if form.Get("grant_type") != grantTypeJwtBearer || form.Get("assertion") != "env-jwt" || form.Get("subject_token") != "" {
+			t.Errorf("unexpected jwt bearer request: %v", form)
+		}
+	})
+	defer server.Close()
+
+	t.Setenv("KEYCLOAK_TEST_SUBJECT_TOKEN", "env-jwt")
+
+	subjectToken := &SubjectToken{
+		EnvVar:    "KEYCLOAK_TEST_SUBJECT_TOKEN",
+		GrantType: "jwt-bearer",
+	}
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), &KeycloakClientConfig{
+		Url:           server.URL,
+		ClientId:      "terraform",
+		Realm:         "master",
+		ClientTimeout: 5,
+		SubjectToken:  subjectToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keycloakClient.tokenManager.token(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubjectToken_empty(t *testing.T) {
+	t.Setenv("KEYCLOAK_TEST_SUBJECT_TOKEN", "")
+
+	subjectToken := &SubjectToken{EnvVar: "KEYCLOAK_TEST_SUBJECT_TOKEN"}
+
+	if _, err := subjectToken.read(); err == nil {
+		t.Fatal("expected an error for an empty subject token")
+	}
+}
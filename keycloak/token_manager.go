@@ -0,0 +1,172 @@
+package keycloak
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Tokens are renewed this long before they expire, or after three quarters of their lifetime for short-lived tokens
+const maxTokenExpiryMargin = 30 * time.Second
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
+}
+
+type oauthToken struct {
+	accessToken      string
+	refreshToken     string
+	tokenType        string
+	renewAt          time.Time // zero when Keycloak did not report a lifetime
+	refreshExpiresAt time.Time // zero when the refresh token does not expire
+}
+
+type tokenRenewal struct {
+	done  chan struct{}
+	token *oauthToken
+	err   error
+}
+
+// tokenManager hands out access tokens to concurrent requests. Tokens are renewed shortly before they expire, and
+// concurrent renewals are collapsed into a single call to the token endpoint.
+type tokenManager struct {
+	login   func(ctx context.Context) (*tokenResponse, error)
+	refresh func(ctx context.Context, refreshToken string) (*tokenResponse, error)
+	now     func() time.Time
+
+	mutex       sync.Mutex
+	current     *oauthToken
+	invalidated bool
+	renewal     *tokenRenewal
+}
+
+func newTokenManager(login func(ctx context.Context) (*tokenResponse, error), refresh func(ctx context.Context, refreshToken string) (*tokenResponse, error)) *tokenManager {
+	return &tokenManager{
+		login:   login,
+		refresh: refresh,
+		now:     time.Now,
+	}
+}
+
+func newOAuthToken(response *tokenResponse, issuedAt time.Time) *oauthToken {
+	token := &oauthToken{
+		accessToken:  response.AccessToken,
+		refreshToken: response.RefreshToken,
+		tokenType:    response.TokenType,
+	}
+
+	if response.ExpiresIn > 0 {
+		lifetime := time.Duration(response.ExpiresIn) * time.Second
+		token.renewAt = issuedAt.Add(lifetime - tokenExpiryMargin(lifetime))
+	}
+
+	if response.RefreshExpiresIn > 0 {
+		lifetime := time.Duration(response.RefreshExpiresIn) * time.Second
+		token.refreshExpiresAt = issuedAt.Add(lifetime - tokenExpiryMargin(lifetime))
+	}
+
+	return token
+}
+
+func tokenExpiryMargin(lifetime time.Duration) time.Duration {
+	return min(maxTokenExpiryMargin, lifetime/4)
+}
+
+func (token *oauthToken) needsRenewal(now time.Time) bool {
+	return !token.renewAt.IsZero() && !now.Before(token.renewAt)
+}
+
+func (token *oauthToken) canRefresh(now time.Time) bool {
+	return token.refreshToken != "" && (token.refreshExpiresAt.IsZero() || now.Before(token.refreshExpiresAt))
+}
+
+// token returns a valid access token, logging in or refreshing first if necessary
+func (manager *tokenManager) token(ctx context.Context) (*oauthToken, error) {
+	manager.mutex.Lock()
+
+	if manager.current != nil && !manager.invalidated && !manager.current.needsRenewal(manager.now()) {
+		current := manager.current
+		manager.mutex.Unlock()
+
+		return current, nil
+	}
+
+	if renewal := manager.renewal; renewal != nil {
+		manager.mutex.Unlock()
+
+		return renewal.wait(ctx)
+	}
+
+	renewal := &tokenRenewal{
+		done: make(chan struct{}),
+	}
+	manager.renewal = renewal
+	previous := manager.current
+	manager.mutex.Unlock()
+
+	// the renewal is shared with every caller waiting for it, so cancelling the request that started it must not cancel it
+	go manager.completeRenewal(context.WithoutCancel(ctx), renewal, previous)
+
+	return renewal.wait(ctx)
+}
+
+func (manager *tokenManager) completeRenewal(ctx context.Context, renewal *tokenRenewal, previous *oauthToken) {
+	renewal.token, renewal.err = manager.renew(ctx, previous)
+
+	manager.mutex.Lock()
+	if renewal.err == nil {
+		manager.current = renewal.token
+		manager.invalidated = false
+	}
+	manager.renewal = nil
+	manager.mutex.Unlock()
+
+	close(renewal.done)
+}
+
+func (renewal *tokenRenewal) wait(ctx context.Context) (*oauthToken, error) {
+	select {
+	case <-renewal.done:
+		return renewal.token, renewal.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// invalidate forces the next call to token to renew, unless the given token has already been replaced
+func (manager *tokenManager) invalidate(token *oauthToken) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if manager.current == token {
+		manager.invalidated = true
+	}
+}
+
+func (manager *tokenManager) renew(ctx context.Context, previous *oauthToken) (*oauthToken, error) {
+	if manager.refresh != nil && previous != nil && previous.canRefresh(manager.now()) {
+		issuedAt := manager.now()
+		response, err := manager.refresh(ctx, previous.refreshToken)
+		if err == nil {
+			return newOAuthToken(response, issuedAt), nil
+		}
+
+		tflog.Debug(ctx, "Failed to refresh access token, attempting to log in again", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	issuedAt := manager.now()
+	response, err := manager.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOAuthToken(response, issuedAt), nil
+}
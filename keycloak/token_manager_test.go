@@ -0,0 +1,235 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeTokenEndpoint struct {
+	logins     int32
+	refreshes  int32
+	refreshErr error
+	release    chan struct{}
+}
+
+func (endpoint *fakeTokenEndpoint) login(ctx context.Context) (*tokenResponse, error) {
+	if endpoint.release != nil {
+		select {
+		case <-endpoint.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	n := atomic.AddInt32(&endpoint.logins, 1)
+
+	return &tokenResponse{
+		AccessToken:      fmt.Sprintf("access-%d", n),
+		RefreshToken:     fmt.Sprintf("refresh-%d", n),
+		TokenType:        "Bearer",
+		ExpiresIn:        300,
+		RefreshExpiresIn: 1800,
+	}, nil
+}
+
+func (endpoint *fakeTokenEndpoint) refresh(ctx context.Context, refreshToken string) (*tokenResponse, error) {
+	atomic.AddInt32(&endpoint.refreshes, 1)
+
+	if endpoint.refreshErr != nil {
+		return nil, endpoint.refreshErr
+	}
+
+	return &tokenResponse{
+		AccessToken:      "refreshed-" + refreshToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        300,
+		RefreshExpiresIn: 1800,
+	}, nil
+}
+
+func TestTokenManager_concurrentLoginsAreCollapsed(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{release: make(chan struct{})}
+	manager := newTokenManager(endpoint.login, endpoint.refresh)
+
+	var wg sync.WaitGroup
+	tokens := make([]*oauthToken, 50)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			token, err := manager.token(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			tokens[i] = token
+		}(i)
+	}
+
+	// give every goroutine a chance to queue up behind the first renewal
+	time.Sleep(50 * time.Millisecond)
+	close(endpoint.release)
+	wg.Wait()
+
+	if endpoint.logins != 1 {
+		t.Fatalf("expected exactly one login, got %d", endpoint.logins)
+	}
+
+	for _, token := range tokens {
+		if token == nil || token.accessToken != "access-1" {
+			t.Fatalf("expected every caller to receive the same token, got %+v", token)
+		}
+	}
+}
+
+func TestTokenManager_cancellingFirstCallerDoesNotFailWaiters(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{release: make(chan struct{})}
+	manager := newTokenManager(endpoint.login, endpoint.refresh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := manager.token(ctx)
+		firstErr <- err
+	}()
+
+	// wait for the first caller to start the shared renewal
+	for {
+		manager.mutex.Lock()
+		started := manager.renewal != nil
+		manager.mutex.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	type result struct {
+		token *oauthToken
+		err   error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		token, err := manager.token(context.Background())
+		waiter <- result{token, err}
+	}()
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled caller to return context.Canceled, got %v", err)
+	}
+
+	close(endpoint.release)
+	waited := <-waiter
+	if waited.err != nil {
+		t.Fatalf("expected the waiting caller to receive a token, got %v", waited.err)
+	}
+	if waited.token.accessToken != "access-1" {
+		t.Fatalf("expected the waiting caller to receive the renewed token, got %s", waited.token.accessToken)
+	}
+}
+
+func TestTokenManager_renewsBeforeExpiry(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{}
+	manager := newTokenManager(endpoint.login, endpoint.refresh)
+
+	now := time.Now()
+	manager.now = func() time.Time { return now }
+
+	token, err := manager.token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// still well within the token lifetime
+	now = now.Add(4 * time.Minute)
+	if cached, _ := manager.token(context.Background()); cached != token {
+		t.Fatalf("expected cached token to be reused")
+	}
+
+	// inside the expiry margin, so the token should be refreshed proactively
+	now = now.Add(45 * time.Second)
+	refreshed, err := manager.token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if refreshed.accessToken != "refreshed-refresh-1" {
+		t.Fatalf("expected token to be refreshed, got %s", refreshed.accessToken)
+	}
+
+	if endpoint.logins != 1 || endpoint.refreshes != 1 {
+		t.Fatalf("expected one login and one refresh, got %d logins and %d refreshes", endpoint.logins, endpoint.refreshes)
+	}
+}
+
+func TestTokenManager_logsInWhenRefreshTokenExpired(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{}
+	manager := newTokenManager(endpoint.login, endpoint.refresh)
+
+	now := time.Now()
+	manager.now = func() time.Time { return now }
+
+	if _, err := manager.token(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	now = now.Add(time.Hour)
+	token, err := manager.token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if token.accessToken != "access-2" || endpoint.refreshes != 0 {
+		t.Fatalf("expected a new login without refreshing, got %s after %d refreshes", token.accessToken, endpoint.refreshes)
+	}
+}
+
+func TestTokenManager_fallsBackToLoginWhenRefreshFails(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{refreshErr: errors.New("invalid_grant")}
+	manager := newTokenManager(endpoint.login, endpoint.refresh)
+
+	token, err := manager.token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	manager.invalidate(token)
+
+	token, err = manager.token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if token.accessToken != "access-2" || endpoint.refreshes != 1 {
+		t.Fatalf("expected a refresh attempt followed by a login, got %s after %d refreshes", token.accessToken, endpoint.refreshes)
+	}
+}
+
+func TestTokenManager_invalidateIgnoresReplacedTokens(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{}
+	manager := newTokenManager(endpoint.login, endpoint.refresh)
+
+	stale, _ := manager.token(context.Background())
+	manager.invalidate(stale)
+
+	current, _ := manager.token(context.Background())
+
+	// a late 401 for the old token must not discard the token that replaced it
+	manager.invalidate(stale)
+
+	if token, _ := manager.token(context.Background()); token != current {
+		t.Fatalf("expected current token to be kept")
+	}
+
+	if endpoint.refreshes != 1 {
+		t.Fatalf("expected exactly one refresh, got %d", endpoint.refreshes)
+	}
+}
@@ -79,9 +79,7 @@ func (keycloakClient *KeycloakClient) ResetUserPassword(ctx context.Context, rea
 }
 
 func (keycloakClient *KeycloakClient) GetUsers(ctx context.Context, realmId string) ([]*User, error) {
-	var users []*User
-
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/users", realmId), &users, nil)
+	users, err := getPaginated[*User](ctx, keycloakClient, fmt.Sprintf("/realms/%s/users", realmId), nil)
 	if err != nil {
 		return nil, err
 	}
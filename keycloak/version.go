@@ -31,11 +31,9 @@ const (
 )
 
 func (keycloakClient *KeycloakClient) VersionIsGreaterThanOrEqualTo(ctx context.Context, versionString Version) (bool, error) {
-	if keycloakClient.version == nil {
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return false, err
-		}
+	serverVersion, err := keycloakClient.serverVersion(ctx)
+	if err != nil {
+		return false, err
 	}
 
 	v, err := version.NewVersion(string(versionString))
@@ -43,15 +41,13 @@ func (keycloakClient *KeycloakClient) VersionIsGreaterThanOrEqualTo(ctx context.
 		return false, nil
 	}
 
-	return keycloakClient.version.GreaterThanOrEqual(v), nil
+	return serverVersion.GreaterThanOrEqual(v), nil
 }
 
 func (keycloakClient *KeycloakClient) VersionIsLessThanOrEqualTo(ctx context.Context, versionString Version) (bool, error) {
-	if keycloakClient.version == nil {
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return false, err
-		}
+	serverVersion, err := keycloakClient.serverVersion(ctx)
+	if err != nil {
+		return false, err
 	}
 
 	v, err := version.NewVersion(string(versionString))
@@ -59,5 +55,5 @@ func (keycloakClient *KeycloakClient) VersionIsLessThanOrEqualTo(ctx context.Con
 		return false, nil
 	}
 
-	return keycloakClient.version.LessThanOrEqual(v), nil
+	return serverVersion.LessThanOrEqual(v), nil
 }
@@ -1,15 +1,20 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/mrparkers/terraform-provider-keycloak/generate"
 	"github.com/mrparkers/terraform-provider-keycloak/provider"
 )
 
 func main() {
+	// Terraform starts the provider without any arguments, so this can't conflict with serving the plugin
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate.Run(os.Args[2:], os.Stderr))
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return provider.KeycloakProvider(nil)
-		},
+		ProviderFunc: provider.KeycloakProvider,
 	})
 }
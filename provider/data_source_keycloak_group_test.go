@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -65,6 +66,36 @@ func TestAccKeycloakDataSourceGroup_nested(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakDataSourceGroup_path(t *testing.T) {
+	t.Parallel()
+
+	firstParent := acctest.RandomWithPrefix("tf-acc")
+	secondParent := acctest.RandomWithPrefix("tf-acc")
+	child := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakGroupDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakGroup_path(firstParent, secondParent, child),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("keycloak_group.second_child", "id", "data.keycloak_group.group", "id"),
+					resource.TestCheckResourceAttrPair("keycloak_group.second_parent", "id", "data.keycloak_group.group", "parent_id"),
+					resource.TestCheckResourceAttr("data.keycloak_group.group", "name", child),
+					resource.TestCheckResourceAttr("data.keycloak_group.group", "path", fmt.Sprintf("/%s/%s", secondParent, child)),
+					testAccCheckDataKeycloakGroup("data.keycloak_group.group"),
+				),
+			},
+			{
+				Config:      testDataSourceKeycloakGroup_ambiguousName(firstParent, secondParent, child),
+				ExpectError: regexp.MustCompile("found 2 groups with name " + child),
+			},
+		},
+	})
+}
+
 func testAccCheckDataKeycloakGroup(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -154,3 +185,83 @@ data "keycloak_group" "group_nested" {
 }
 	`, testAccRealm.Realm, group, groupNested)
 }
+
+func testDataSourceKeycloakGroup_path(firstParent, secondParent, child string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_group" "first_parent" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "second_parent" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+# both children have the same name, so only their paths tell them apart
+resource "keycloak_group" "first_child" {
+	name      = "%s"
+	parent_id = keycloak_group.first_parent.id
+	realm_id  = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "second_child" {
+	name      = "%s"
+	parent_id = keycloak_group.second_parent.id
+	realm_id  = data.keycloak_realm.realm.id
+}
+
+data "keycloak_group" "group" {
+	realm_id = data.keycloak_realm.realm.id
+	path     = "/${keycloak_group.second_parent.name}/${keycloak_group.second_child.name}"
+
+	depends_on = [
+		keycloak_group.first_child,
+		keycloak_group.second_child,
+	]
+}
+	`, testAccRealm.Realm, firstParent, secondParent, child, child)
+}
+
+func testDataSourceKeycloakGroup_ambiguousName(firstParent, secondParent, child string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_group" "first_parent" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "second_parent" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "first_child" {
+	name      = "%s"
+	parent_id = keycloak_group.first_parent.id
+	realm_id  = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "second_child" {
+	name      = "%s"
+	parent_id = keycloak_group.second_parent.id
+	realm_id  = data.keycloak_realm.realm.id
+}
+
+data "keycloak_group" "group" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = keycloak_group.second_child.name
+
+	depends_on = [
+		keycloak_group.first_child,
+	]
+}
+	`, testAccRealm.Realm, firstParent, secondParent, child, child)
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func policyEvaluationResultSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceKeycloakOpenidClientAuthorizationPolicyEvaluation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakOpenidClientAuthorizationPolicyEvaluationRead,
+
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"resource_server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID or username of the user to evaluate the permissions for",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the client the user is authenticated with. Defaults to the resource server",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "The names of roles granted to the user in addition to their own for this evaluation",
+			},
+			"context_attributes": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"resource": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The resources to evaluate. When not set, all resources are evaluated",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"scopes": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Optional: true,
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"allowed_scopes": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+						"permissions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: mergeSchemas(policyEvaluationResultSchema(), map[string]*schema.Schema{
+									"scopes": {
+										Type:     schema.TypeList,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Computed: true,
+									},
+									"policies": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: policyEvaluationResultSchema(),
+										},
+									},
+								}),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getOpenidClientAuthorizationPolicyEvaluationRequestFromData(data *schema.ResourceData) (*keycloak.OpenidClientAuthorizationPolicyEvaluationRequest, error) {
+	request := &keycloak.OpenidClientAuthorizationPolicyEvaluationRequest{
+		RealmId:          data.Get("realm_id").(string),
+		ResourceServerId: data.Get("resource_server_id").(string),
+		UserId:           data.Get("user_id").(string),
+		ClientId:         data.Get("client_id").(string),
+		RoleIds:          interfaceSliceToStringSlice(data.Get("roles").(*schema.Set).List()),
+		Resources:        []keycloak.OpenidClientAuthorizationPolicyEvaluationResource{},
+		Context: keycloak.OpenidClientAuthorizationPolicyEvaluationContext{
+			Attributes: map[string]string{},
+		},
+	}
+
+	for key, value := range data.Get("context_attributes").(map[string]interface{}) {
+		request.Context.Attributes[key] = value.(string)
+	}
+
+	for _, r := range data.Get("resource").([]interface{}) {
+		resourceData := r.(map[string]interface{})
+
+		resource := keycloak.OpenidClientAuthorizationPolicyEvaluationResource{
+			Id:   resourceData["id"].(string),
+			Name: resourceData["name"].(string),
+		}
+		if resource.Id == "" && resource.Name == "" {
+			return nil, fmt.Errorf("either id or name must be set for each resource")
+		}
+
+		for _, scope := range resourceData["scopes"].(*schema.Set).List() {
+			resource.Scopes = append(resource.Scopes, keycloak.OpenidClientAuthorizationPolicyEvaluationScope{Name: scope.(string)})
+		}
+
+		request.Resources = append(request.Resources, resource)
+	}
+
+	return request, nil
+}
+
+func flattenOpenidClientAuthorizationPolicyEvaluationPolicyResult(result keycloak.OpenidClientAuthorizationPolicyEvaluationPolicyResult) map[string]interface{} {
+	return map[string]interface{}{
+		"name":   result.Policy.Name,
+		"type":   result.Policy.Type,
+		"status": result.Status,
+	}
+}
+
+func setOpenidClientAuthorizationPolicyEvaluationData(data *schema.ResourceData, response *keycloak.OpenidClientAuthorizationPolicyEvaluationResponse) {
+	var results []interface{}
+
+	for _, result := range response.Results {
+		var allowedScopes []string
+		for _, scope := range result.AllowedScopes {
+			allowedScopes = append(allowedScopes, scope.Name)
+		}
+
+		var permissions []interface{}
+		for _, permission := range result.Policies {
+			var policies []interface{}
+			for _, policy := range permission.AssociatedPolicies {
+				policies = append(policies, flattenOpenidClientAuthorizationPolicyEvaluationPolicyResult(policy))
+			}
+
+			flattenedPermission := flattenOpenidClientAuthorizationPolicyEvaluationPolicyResult(permission)
+			flattenedPermission["scopes"] = permission.Scopes
+			flattenedPermission["policies"] = policies
+
+			permissions = append(permissions, flattenedPermission)
+		}
+
+		results = append(results, map[string]interface{}{
+			"resource_id":    result.Resource.Id,
+			"resource_name":  result.Resource.Name,
+			"status":         result.Status,
+			"allowed_scopes": allowedScopes,
+			"permissions":    permissions,
+		})
+	}
+
+	data.Set("status", response.Status)
+	data.Set("results", results)
+}
+
+func dataSourceKeycloakOpenidClientAuthorizationPolicyEvaluationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	request, err := getOpenidClientAuthorizationPolicyEvaluationRequestFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, err := keycloakClient.EvaluateOpenidClientAuthorizationPolicies(ctx, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(fmt.Sprintf("%s/%s/%s", request.RealmId, request.ResourceServerId, request.UserId))
+
+	setOpenidClientAuthorizationPolicyEvaluationData(data, response)
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceOpenidClientAuthorizationPolicyEvaluation_basic(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	roleName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+	denied := "data.keycloak_openid_client_authorization_policy_evaluation.denied"
+	permitted := "data.keycloak_openid_client_authorization_policy_evaluation.permitted"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeycloakOpenidClientAuthorizationPolicyEvaluationConfig(clientId, roleName, username),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(denied, "status", "DENY"),
+					resource.TestCheckResourceAttr(denied, "results.#", "1"),
+					resource.TestCheckResourceAttr(denied, "results.0.resource_name", "billing"),
+					resource.TestCheckResourceAttr(denied, "results.0.status", "DENY"),
+					resource.TestCheckResourceAttr(denied, "results.0.permissions.0.name", "billing-permission"),
+					resource.TestCheckResourceAttr(denied, "results.0.permissions.0.policies.0.name", "billing-admins"),
+					resource.TestCheckResourceAttr(denied, "results.0.permissions.0.policies.0.status", "DENY"),
+					resource.TestCheckResourceAttr(permitted, "status", "PERMIT"),
+					resource.TestCheckResourceAttr(permitted, "results.0.status", "PERMIT"),
+					resource.TestCheckResourceAttr(permitted, "results.0.permissions.0.policies.0.status", "PERMIT"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKeycloakOpenidClientAuthorizationPolicyEvaluationConfig(clientId, roleName, username string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "test" {
+	client_id                = "%s"
+	realm_id                 = data.keycloak_realm.realm.id
+	access_type              = "CONFIDENTIAL"
+	service_accounts_enabled = true
+	authorization {
+		policy_enforcement_mode = "ENFORCING"
+	}
+}
+
+resource "keycloak_role" "billing_admin" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+}
+
+resource "keycloak_user" "test" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+}
+
+resource "keycloak_openid_client_authorization_resource" "billing" {
+	resource_server_id = keycloak_openid_client.test.resource_server_id
+	realm_id           = data.keycloak_realm.realm.id
+	name               = "billing"
+	uris               = ["/billing/*"]
+}
+
+resource "keycloak_openid_client_role_policy" "billing_admins" {
+	resource_server_id = keycloak_openid_client.test.resource_server_id
+	realm_id           = data.keycloak_realm.realm.id
+	name               = "billing-admins"
+	decision_strategy  = "UNANIMOUS"
+	logic              = "POSITIVE"
+	type               = "role"
+	role {
+		id       = keycloak_role.billing_admin.id
+		required = false
+	}
+}
+
+resource "keycloak_openid_client_authorization_permission" "billing" {
+	resource_server_id = keycloak_openid_client.test.resource_server_id
+	realm_id           = data.keycloak_realm.realm.id
+	name               = "billing-permission"
+	policies           = [keycloak_openid_client_role_policy.billing_admins.id]
+	resources          = [keycloak_openid_client_authorization_resource.billing.id]
+}
+
+data "keycloak_openid_client_authorization_policy_evaluation" "denied" {
+	realm_id           = data.keycloak_realm.realm.id
+	resource_server_id = keycloak_openid_client.test.resource_server_id
+	user_id            = keycloak_user.test.id
+
+	resource {
+		name = keycloak_openid_client_authorization_resource.billing.name
+	}
+
+	depends_on = [keycloak_openid_client_authorization_permission.billing]
+}
+
+data "keycloak_openid_client_authorization_policy_evaluation" "permitted" {
+	realm_id           = data.keycloak_realm.realm.id
+	resource_server_id = keycloak_openid_client.test.resource_server_id
+	user_id            = keycloak_user.test.id
+	roles              = [keycloak_role.billing_admin.name]
+
+	resource {
+		id = keycloak_openid_client_authorization_resource.billing.id
+	}
+
+	depends_on = [keycloak_openid_client_authorization_permission.billing]
+}
+	`, testAccRealm.Realm, clientId, roleName, username)
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakOpenidClientTokenEvaluation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakOpenidClientTokenEvaluationRead,
+
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the client, not the client_id attribute",
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "openid",
+				Description: "A space separated list of scopes, as sent in the scope parameter of an authorization request",
+			},
+			"access_token_claims": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"access_token_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"id_token_claims": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"id_token_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"userinfo_claims": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"userinfo_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Terraform maps can only hold strings, so claims that aren't strings (numbers, lists, objects) are encoded as JSON
+func flattenTokenClaims(claims map[string]interface{}) (map[string]string, error) {
+	flattened := make(map[string]string, len(claims))
+
+	for key, value := range claims {
+		if s, ok := value.(string); ok {
+			flattened[key] = s
+			continue
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		flattened[key] = string(encoded)
+	}
+
+	return flattened, nil
+}
+
+func setTokenClaimsData(data *schema.ResourceData, prefix string, claims map[string]interface{}) error {
+	flattened, err := flattenTokenClaims(claims)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return err
+	}
+
+	data.Set(prefix+"_claims", flattened)
+	data.Set(prefix+"_json", string(encoded))
+
+	return nil
+}
+
+func dataSourceKeycloakOpenidClientTokenEvaluationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	clientId := data.Get("client_id").(string)
+	userId := data.Get("user_id").(string)
+	scope := data.Get("scope").(string)
+
+	tokens, err := keycloakClient.GenerateOpenidClientExampleTokens(ctx, realmId, clientId, userId, scope)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(fmt.Sprintf("%s/%s/%s", realmId, clientId, userId))
+
+	if err := setTokenClaimsData(data, "access_token", tokens.AccessToken); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setTokenClaimsData(data, "id_token", tokens.IdToken); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := setTokenClaimsData(data, "userinfo", tokens.UserInfo); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceOpenidClientTokenEvaluation_basic(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+	dataSourceName := "data.keycloak_openid_client_token_evaluation.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeycloakOpenidClientTokenEvaluationConfig(clientId, username),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "access_token_claims.preferred_username", username),
+					resource.TestCheckResourceAttr(dataSourceName, "access_token_claims.department", "engineering"),
+					resource.TestCheckResourceAttr(dataSourceName, "id_token_claims.department", "engineering"),
+					resource.TestCheckNoResourceAttr(dataSourceName, "userinfo_claims.department"),
+					resource.TestCheckResourceAttr(dataSourceName, "userinfo_claims.preferred_username", username),
+					resource.TestMatchResourceAttr(dataSourceName, "access_token_json", regexp.MustCompile(`"department":"engineering"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccKeycloakOpenidClientTokenEvaluationConfig(clientId, username string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "test" {
+	client_id             = "%s"
+	realm_id              = data.keycloak_realm.realm.id
+	access_type           = "CONFIDENTIAL"
+	standard_flow_enabled = true
+	valid_redirect_uris   = ["http://localhost:8080/*"]
+}
+
+resource "keycloak_openid_hardcoded_claim_protocol_mapper" "department" {
+	name      = "department"
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.test.id
+
+	claim_name          = "department"
+	claim_value         = "engineering"
+	claim_value_type    = "String"
+	add_to_access_token = true
+	add_to_id_token     = true
+	add_to_userinfo     = false
+}
+
+resource "keycloak_user" "test" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+}
+
+data "keycloak_openid_client_token_evaluation" "test" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = keycloak_openid_client.test.id
+	user_id   = keycloak_user.test.id
+	scope     = "openid profile"
+
+	depends_on = [keycloak_openid_hardcoded_claim_protocol_mapper.department]
+}
+	`, testAccRealm.Realm, clientId, username)
+}
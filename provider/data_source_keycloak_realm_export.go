@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakRealmExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmExportRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"export_clients": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"export_groups_and_roles": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"strip_ids": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Remove the IDs generated by Keycloak from the export, so exports of different environments can be compared",
+			},
+			"realm_json": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakRealmExportRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmJson, err := keycloakClient.GetRealmExport(ctx, realmId, data.Get("export_clients").(bool), data.Get("export_groups_and_roles").(bool), data.Get("strip_ids").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(realmId)
+	data.Set("realm_json", realmJson)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceRealmExport_basic(t *testing.T) {
+	realm := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	dataSourceName := "data.keycloak_realm_export.export"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmExport_basic(realm, clientId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", realm),
+					resource.TestCheckResourceAttrWith(dataSourceName, "realm_json", func(realmJson string) error {
+						var export struct {
+							Id      string `json:"id"`
+							Realm   string `json:"realm"`
+							Clients []struct {
+								Id       string `json:"id"`
+								ClientId string `json:"clientId"`
+								Secret   string `json:"secret"`
+							} `json:"clients"`
+						}
+
+						if err := json.Unmarshal([]byte(realmJson), &export); err != nil {
+							return err
+						}
+
+						if export.Realm != realm || export.Id != "" {
+							return fmt.Errorf("expected export of realm %s without IDs, got realm %s with ID %s", realm, export.Realm, export.Id)
+						}
+
+						for _, client := range export.Clients {
+							if client.ClientId == clientId {
+								if client.Id != "" || client.Secret == "super-secret" {
+									return fmt.Errorf("expected client %s to be exported without ID and with masked secret", clientId)
+								}
+
+								return nil
+							}
+						}
+
+						return fmt.Errorf("expected client %s to be exported", clientId)
+					}),
+				),
+			},
+			{
+				// reading the same realm again must not produce a diff
+				Config:   testDataSourceKeycloakRealmExport_basic(realm, clientId),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testDataSourceKeycloakRealmExport_basic(realm, clientId string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id      = keycloak_realm.realm.id
+	client_id     = "%s"
+	access_type   = "CONFIDENTIAL"
+	client_secret = "super-secret"
+}
+
+data "keycloak_realm_export" "export" {
+	realm_id                = keycloak_realm.realm.id
+	export_clients          = true
+	export_groups_and_roles = true
+	strip_ids               = true
+
+	depends_on = [
+		keycloak_openid_client.client
+	]
+}
+	`, realm, clientId)
+}
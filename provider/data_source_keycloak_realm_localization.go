@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+var realmLocalizationThemeTypes = []string{"login", "account", "email", "admin"}
+
+func dataSourceKeycloakRealmLocalization() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmLocalizationRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"locale": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"use_realm_default_locale_fallback": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include the texts that are only overridden for the default locale of the realm",
+			},
+			"theme_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "login",
+				ValidateFunc: validation.StringInSlice(realmLocalizationThemeTypes, false),
+				Description:  "The type of theme whose messages are included in messages",
+			},
+			"theme": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The theme whose messages are included in messages. Defaults to the theme of the realm",
+			},
+			"texts": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "The texts overridden by the realm",
+			},
+			"messages": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "The effective messages: the messages of the theme, with the texts overridden by the realm on top",
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakRealmLocalizationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+
+	texts, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale, data.Get("use_realm_default_locale_fallback").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	messages, err := keycloakClient.GetRealmEffectiveMessages(ctx, realmId, data.Get("theme_type").(string), data.Get("theme").(string), locale)
+	if err != nil {
+		if !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+
+		// older versions of Keycloak do not serve the messages of a theme
+		tflog.Warn(ctx, "Unable to read the messages of the theme, only the texts overridden by the realm are included in messages", map[string]interface{}{
+			"realm_id": realmId,
+			"locale":   locale,
+		})
+
+		messages = map[string]string{}
+	}
+
+	for key, text := range texts {
+		messages[key] = text
+	}
+
+	data.SetId(realmLocalizationId(realmId, locale))
+	data.Set("texts", texts)
+	data.Set("messages", messages)
+
+	return nil
+}
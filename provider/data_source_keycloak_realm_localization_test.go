@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceRealmLocalization_basic(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmLocalization_basic(realmName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keycloak_realm_localization.de", "texts.%", "1"),
+					resource.TestCheckResourceAttr("data.keycloak_realm_localization.de", "texts.loginTitle", "Anmelden"),
+					resource.TestCheckResourceAttr("data.keycloak_realm_localization.de", "messages.loginTitle", "Anmelden"),
+					resource.TestCheckResourceAttrSet("data.keycloak_realm_localization.de", "messages.doLogIn"),
+					resource.TestCheckResourceAttr("data.keycloak_realm_localization.de_with_fallback", "texts.%", "2"),
+					resource.TestCheckResourceAttr("data.keycloak_realm_localization.de_with_fallback", "texts.emailVerify", "Please verify your email"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKeycloakRealmLocalization_basic(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+	internationalization {
+		supported_locales = ["en", "de"]
+		default_locale    = "en"
+	}
+}
+
+resource "keycloak_realm_localization" "en" {
+	realm_id = keycloak_realm.realm.id
+	locale   = "en"
+
+	texts = {
+		loginTitle  = "Sign in"
+		emailVerify = "Please verify your email"
+	}
+}
+
+resource "keycloak_realm_localization" "de" {
+	realm_id = keycloak_realm.realm.id
+	locale   = "de"
+
+	texts = {
+		loginTitle = "Anmelden"
+	}
+}
+
+data "keycloak_realm_localization" "de" {
+	realm_id = keycloak_realm.realm.id
+	locale   = keycloak_realm_localization.de.locale
+
+	depends_on = [keycloak_realm_localization.en]
+}
+
+data "keycloak_realm_localization" "de_with_fallback" {
+	realm_id                          = keycloak_realm.realm.id
+	locale                            = keycloak_realm_localization.de.locale
+	use_realm_default_locale_fallback = true
+
+	depends_on = [keycloak_realm_localization.en]
+}
+	`, realm)
+}
@@ -3,31 +3,40 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
 )
 
-func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
+// KeycloakProvider returns a new instance of the provider. Every provider configuration, including each alias,
+// creates its own KeycloakClient when it is configured.
+func KeycloakProvider() *schema.Provider {
 	provider := &schema.Provider{
 		DataSourcesMap: map[string]*schema.Resource{
-			"keycloak_group":                              dataSourceKeycloakGroup(),
-			"keycloak_openid_client":                      dataSourceKeycloakOpenidClient(),
-			"keycloak_openid_client_authorization_policy": dataSourceKeycloakOpenidClientAuthorizationPolicy(),
-			"keycloak_openid_client_scope":                dataSourceKeycloakOpenidClientScope(),
-			"keycloak_openid_client_service_account_user": dataSourceKeycloakOpenidClientServiceAccountUser(),
-			"keycloak_realm":                              dataSourceKeycloakRealm(),
-			"keycloak_realm_keys":                         dataSourceKeycloakRealmKeys(),
-			"keycloak_role":                               dataSourceKeycloakRole(),
-			"keycloak_user":                               dataSourceKeycloakUser(),
-			"keycloak_user_realm_roles":                   dataSourceKeycloakUserRealmRoles(),
-			"keycloak_saml_client_installation_provider":  dataSourceKeycloakSamlClientInstallationProvider(),
-			"keycloak_saml_client":                        dataSourceKeycloakSamlClient(),
-			"keycloak_authentication_execution":           dataSourceKeycloakAuthenticationExecution(),
-			"keycloak_authentication_flow":                dataSourceKeycloakAuthenticationFlow(),
-			"keycloak_client_description_converter":       dataSourceKeycloakClientDescriptionConverter(),
+			"keycloak_group":                                         dataSourceKeycloakGroup(),
+			"keycloak_openid_client":                                 dataSourceKeycloakOpenidClient(),
+			"keycloak_openid_client_authorization_policy":            dataSourceKeycloakOpenidClientAuthorizationPolicy(),
+			"keycloak_openid_client_authorization_policy_evaluation": dataSourceKeycloakOpenidClientAuthorizationPolicyEvaluation(),
+			"keycloak_openid_client_scope":                           dataSourceKeycloakOpenidClientScope(),
+			"keycloak_openid_client_service_account_user":            dataSourceKeycloakOpenidClientServiceAccountUser(),
+			"keycloak_openid_client_token_evaluation":                dataSourceKeycloakOpenidClientTokenEvaluation(),
+			"keycloak_realm":                                         dataSourceKeycloakRealm(),
+			"keycloak_realm_keys":                                    dataSourceKeycloakRealmKeys(),
+			"keycloak_role":                                          dataSourceKeycloakRole(),
+			"keycloak_user":                                          dataSourceKeycloakUser(),
+			"keycloak_user_realm_roles":                              dataSourceKeycloakUserRealmRoles(),
+			"keycloak_saml_client_installation_provider":             dataSourceKeycloakSamlClientInstallationProvider(),
+			"keycloak_saml_client":                                   dataSourceKeycloakSamlClient(),
+			"keycloak_authentication_execution":                      dataSourceKeycloakAuthenticationExecution(),
+			"keycloak_authentication_flow":                           dataSourceKeycloakAuthenticationFlow(),
+			"keycloak_client_description_converter":                  dataSourceKeycloakClientDescriptionConverter(),
+			"keycloak_realm_export":                                  dataSourceKeycloakRealmExport(),
+			"keycloak_realm_localization":                            dataSourceKeycloakRealmLocalization(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"keycloak_realm":                                             resourceKeycloakRealm(),
@@ -39,6 +48,13 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_realm_keystore_rsa":                                resourceKeycloakRealmKeystoreRsa(),
 			"keycloak_realm_keystore_rsa_generated":                      resourceKeycloakRealmKeystoreRsaGenerated(),
 			"keycloak_realm_user_profile":                                resourceKeycloakRealmUserProfile(),
+			"keycloak_realm_partial_import":                              resourceKeycloakRealmPartialImport(),
+			"keycloak_realm_localization":                                resourceKeycloakRealmLocalization(),
+			"keycloak_realm_client_policy_profile":                       resourceKeycloakRealmClientPolicyProfile(),
+			"keycloak_realm_client_policy":                               resourceKeycloakRealmClientPolicy(),
+			"keycloak_organization":                                      resourceKeycloakOrganization(),
+			"keycloak_organization_membership":                           resourceKeycloakOrganizationMembership(),
+			"keycloak_organization_identity_provider":                    resourceKeycloakOrganizationIdentityProvider(),
 			"keycloak_required_action":                                   resourceKeycloakRequiredAction(),
 			"keycloak_group":                                             resourceKeycloakGroup(),
 			"keycloak_group_memberships":                                 resourceKeycloakGroupMemberships(),
@@ -61,6 +77,7 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_ldap_full_name_mapper":                             resourceKeycloakLdapFullNameMapper(),
 			"keycloak_ldap_custom_mapper":                                resourceKeycloakLdapCustomMapper(),
 			"keycloak_custom_user_federation":                            resourceKeycloakCustomUserFederation(),
+			"keycloak_kerberos_user_federation":                          resourceKeycloakKerberosUserFederation(),
 			"keycloak_openid_user_attribute_protocol_mapper":             resourceKeycloakOpenIdUserAttributeProtocolMapper(),
 			"keycloak_openid_user_property_protocol_mapper":              resourceKeycloakOpenIdUserPropertyProtocolMapper(),
 			"keycloak_openid_group_membership_protocol_mapper":           resourceKeycloakOpenIdGroupMembershipProtocolMapper(),
@@ -95,6 +112,7 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_custom_identity_provider_mapper":                   resourceKeycloakCustomIdentityProviderMapper(),
 			"keycloak_saml_identity_provider":                            resourceKeycloakSamlIdentityProvider(),
 			"keycloak_oidc_google_identity_provider":                     resourceKeycloakOidcGoogleIdentityProvider(),
+			"keycloak_social_identity_provider":                          resourceKeycloakSocialIdentityProvider(),
 			"keycloak_oidc_identity_provider":                            resourceKeycloakOidcIdentityProvider(),
 			"keycloak_openid_client_authorization_resource":              resourceKeycloakOpenidClientAuthorizationResource(),
 			"keycloak_openid_client_group_policy":                        resourceKeycloakOpenidClientAuthorizationGroupPolicy(),
@@ -104,7 +122,10 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_openid_client_time_policy":                         resourceKeycloakOpenidClientAuthorizationTimePolicy(),
 			"keycloak_openid_client_user_policy":                         resourceKeycloakOpenidClientAuthorizationUserPolicy(),
 			"keycloak_openid_client_client_policy":                       resourceKeycloakOpenidClientAuthorizationClientPolicy(),
+			"keycloak_openid_client_regex_policy":                        resourceKeycloakOpenidClientAuthorizationRegexPolicy(),
+			"keycloak_openid_client_client_scope_policy":                 resourceKeycloakOpenidClientAuthorizationClientScopePolicy(),
 			"keycloak_openid_client_authorization_scope":                 resourceKeycloakOpenidClientAuthorizationScope(),
+			"keycloak_openid_client_authorization_settings":              resourceKeycloakOpenidClientAuthorizationSettings(),
 			"keycloak_openid_client_authorization_permission":            resourceKeycloakOpenidClientAuthorizationPermission(),
 			"keycloak_openid_client_service_account_role":                resourceKeycloakOpenidClientServiceAccountRole(),
 			"keycloak_openid_client_service_account_realm_role":          resourceKeycloakOpenidClientServiceAccountRealmRole(),
@@ -113,16 +134,18 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_authentication_subflow":                            resourceKeycloakAuthenticationSubFlow(),
 			"keycloak_authentication_execution":                          resourceKeycloakAuthenticationExecution(),
 			"keycloak_authentication_execution_config":                   resourceKeycloakAuthenticationExecutionConfig(),
+			"keycloak_identity_provider_permissions":                     resourceKeycloakIdentityProviderPermissions(),
 			"keycloak_identity_provider_token_exchange_scope_permission": resourceKeycloakIdentityProviderTokenExchangeScopePermission(),
 			"keycloak_openid_client_permissions":                         resourceKeycloakOpenidClientPermissions(),
 			"keycloak_users_permissions":                                 resourceKeycloakUsersPermissions(),
 			"keycloak_user_groups":                                       resourceKeycloakUserGroups(),
 			"keycloak_group_permissions":                                 resourceKeycloakGroupPermissions(),
+			"keycloak_role_permissions":                                  resourceKeycloakRolePermissions(),
 			"keycloak_authentication_bindings":                           resourceKeycloakAuthenticationBindings(),
 		},
 		Schema: map[string]*schema.Schema{
 			"client_id": {
-				Required:    true,
+				Optional:    true,
 				Type:        schema.TypeString,
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_ID", nil),
 			},
@@ -131,6 +154,25 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 				Type:        schema.TypeString,
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_SECRET", nil),
 			},
+			"client_assertion_private_key": {
+				Optional:      true,
+				Sensitive:     true,
+				Type:          schema.TypeString,
+				Description:   "PEM encoded RSA or EC private key, or the path to a file containing it, used to sign a JWT that authenticates the client instead of a client secret",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_CLIENT_ASSERTION_PRIVATE_KEY", nil),
+				ConflictsWith: []string{"client_secret"},
+			},
+			"client_assertion_signing_algorithm": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Description:  "The algorithm used to sign the client assertion. Defaults to RS256 for RSA keys, or the algorithm matching the curve of an EC key.",
+				ValidateFunc: validation.StringInSlice(keycloak.ClientAssertionAlgorithms, false),
+			},
+			"client_assertion_key_id": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The key ID sent in the `kid` header of the client assertion",
+			},
 			"username": {
 				Optional:    true,
 				Type:        schema.TypeString,
@@ -141,6 +183,72 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 				Type:        schema.TypeString,
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_PASSWORD", nil),
 			},
+			"access_token": {
+				Optional:      true,
+				Sensitive:     true,
+				Type:          schema.TypeString,
+				Description:   "An access token obtained outside of the provider, used instead of logging in to Keycloak",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_ACCESS_TOKEN", nil),
+				ConflictsWith: []string{"client_secret", "username", "password", "client_assertion_private_key", "exec"},
+			},
+			"exec": {
+				Optional:      true,
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				Description:   "A command that prints an access token, used instead of logging in to Keycloak. The command is run again whenever the token expires.",
+				ConflictsWith: []string{"client_secret", "username", "password", "client_assertion_private_key"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"subject_token": {
+				Optional:      true,
+				Sensitive:     true,
+				Type:          schema.TypeString,
+				Description:   "A JWT issued by an external identity provider, which is exchanged for a Keycloak access token",
+				ConflictsWith: []string{"subject_token_file", "subject_token_env_var", "username", "password", "access_token", "exec"},
+			},
+			"subject_token_file": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				Description:   "The path to a file containing a JWT issued by an external identity provider, which is exchanged for a Keycloak access token. The file is read again on every login.",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_SUBJECT_TOKEN_FILE", nil),
+				ConflictsWith: []string{"subject_token", "subject_token_env_var", "username", "password", "access_token", "exec"},
+			},
+			"subject_token_env_var": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				Description:   "The name of an environment variable containing a JWT issued by an external identity provider, which is exchanged for a Keycloak access token",
+				ConflictsWith: []string{"subject_token", "subject_token_file", "username", "password", "access_token", "exec"},
+			},
+			"subject_issuer": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "The alias of the identity provider in Keycloak that issued the subject token",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_SUBJECT_ISSUER", ""),
+			},
+			"subject_token_grant_type": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Description:  "The grant used to exchange the subject token for a Keycloak access token. Can be `token-exchange` or `jwt-bearer`.",
+				Default:      "token-exchange",
+				ValidateFunc: validation.StringInSlice(keycloak.SubjectTokenGrantTypes, false),
+			},
 			"realm": {
 				Optional:    true,
 				Type:        schema.TypeString,
@@ -164,12 +272,32 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 				Description: "Timeout (in seconds) of the Keycloak client",
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_TIMEOUT", 15),
 			},
+			"page_size": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "The number of results requested per page when listing users, groups, clients and roles",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_PAGE_SIZE", keycloak.DefaultPageSize),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"root_ca_certificate": {
 				Optional:    true,
 				Type:        schema.TypeString,
 				Description: "Allows x509 calls using an unknown CA certificate (for development purposes)",
 				Default:     "",
 			},
+			"tls_client_certificate": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "PEM encoded certificate, or the path to a file containing it, used for mutual TLS authentication with the Keycloak instance",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_CERTIFICATE", nil),
+			},
+			"tls_client_private_key": {
+				Optional:    true,
+				Sensitive:   true,
+				Type:        schema.TypeString,
+				Description: "PEM encoded private key, or the path to a file containing it, for the certificate set in `tls_client_certificate`",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_PRIVATE_KEY", nil),
+			},
 			"tls_insecure_skip_verify": {
 				Optional:    true,
 				Type:        schema.TypeBool,
@@ -194,14 +322,50 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 					Type: schema.TypeString,
 				},
 			},
+			"max_retries": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of times a failed request to the Keycloak API is retried",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_MAX_RETRIES", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Minimum time (in seconds) to wait before retrying a failed request",
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_max": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum time (in seconds) to wait before retrying a failed request, including delays requested with a Retry-After header",
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_status_codes": {
+				Optional:    true,
+				Type:        schema.TypeSet,
+				Description: "HTTP status codes that cause a request to be retried. Defaults to 429, 502, 503 and 504.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(400, 599),
+				},
+			},
+			"retry_methods": {
+				Optional:    true,
+				Type:        schema.TypeSet,
+				Description: "HTTP methods of requests that may be retried. Defaults to the idempotent methods GET, HEAD, OPTIONS, PUT and DELETE. Add POST to opt into retrying requests that create resources.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodPost, http.MethodPatch}, false),
+				},
+				Set: schema.HashString,
+			},
 		},
 	}
 
 	provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		if client != nil {
-			return client, nil
-		}
-
 		url := data.Get("url").(string)
 		basePath := data.Get("base_path").(string)
 		clientId := data.Get("client_id").(string)
@@ -211,19 +375,108 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 		realm := data.Get("realm").(string)
 		initialLogin := data.Get("initial_login").(bool)
 		clientTimeout := data.Get("client_timeout").(int)
+		pageSize := data.Get("page_size").(int)
 		tlsInsecureSkipVerify := data.Get("tls_insecure_skip_verify").(bool)
 		rootCaCertificate := data.Get("root_ca_certificate").(string)
+		tlsClientCertificate := data.Get("tls_client_certificate").(string)
+		tlsClientPrivateKey := data.Get("tls_client_private_key").(string)
 		redHatSSO := data.Get("red_hat_sso").(bool)
 		additionalHeaders := make(map[string]string)
 		for k, v := range data.Get("additional_headers").(map[string]interface{}) {
 			additionalHeaders[k] = v.(string)
 		}
 
+		var clientAssertion *keycloak.ClientAssertion
+		if clientAssertionPrivateKey := data.Get("client_assertion_private_key").(string); clientAssertionPrivateKey != "" {
+			clientAssertion = &keycloak.ClientAssertion{
+				PrivateKey: clientAssertionPrivateKey,
+				Algorithm:  data.Get("client_assertion_signing_algorithm").(string),
+				KeyId:      data.Get("client_assertion_key_id").(string),
+			}
+		}
+
+		var externalToken *keycloak.ExternalToken
+		if accessToken := data.Get("access_token").(string); accessToken != "" {
+			externalToken = &keycloak.ExternalToken{
+				AccessToken: accessToken,
+			}
+		} else if v, ok := data.GetOk("exec"); ok {
+			execConfig := v.([]interface{})[0].(map[string]interface{})
+			execCredential := &keycloak.ExecCredential{
+				Command: execConfig["command"].(string),
+				Args:    interfaceSliceToStringSlice(execConfig["args"].([]interface{})),
+				Env:     make(map[string]string),
+			}
+			for k, v := range execConfig["env"].(map[string]interface{}) {
+				execCredential.Env[k] = v.(string)
+			}
+			externalToken = &keycloak.ExternalToken{
+				Exec: execCredential,
+			}
+		}
+
+		var subjectToken *keycloak.SubjectToken
+		subjectTokenInline := data.Get("subject_token").(string)
+		subjectTokenFile := data.Get("subject_token_file").(string)
+		subjectTokenEnvVar := data.Get("subject_token_env_var").(string)
+		if subjectTokenInline != "" || subjectTokenFile != "" || subjectTokenEnvVar != "" {
+			subjectToken = &keycloak.SubjectToken{
+				Token:     subjectTokenInline,
+				File:      subjectTokenFile,
+				EnvVar:    subjectTokenEnvVar,
+				Issuer:    data.Get("subject_issuer").(string),
+				GrantType: data.Get("subject_token_grant_type").(string),
+			}
+		}
+
+		retryPolicy := &keycloak.RetryPolicy{
+			MaxRetries:  data.Get("max_retries").(int),
+			WaitMin:     time.Second * time.Duration(data.Get("retry_wait_min").(int)),
+			WaitMax:     time.Second * time.Duration(data.Get("retry_wait_max").(int)),
+			StatusCodes: keycloak.DefaultRetryStatusCodes,
+			Methods:     keycloak.DefaultRetryMethods,
+		}
+		if v, ok := data.GetOk("retry_status_codes"); ok {
+			retryPolicy.StatusCodes = nil
+			for _, code := range v.(*schema.Set).List() {
+				retryPolicy.StatusCodes = append(retryPolicy.StatusCodes, code.(int))
+			}
+		}
+		if v, ok := data.GetOk("retry_methods"); ok {
+			retryPolicy.Methods = interfaceSliceToStringSlice(v.(*schema.Set).List())
+		}
+
 		var diags diag.Diagnostics
 
+		if retryPolicy.WaitMin > retryPolicy.WaitMax {
+			return nil, diag.Errorf("retry_wait_min must not be greater than retry_wait_max")
+		}
+
 		userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", provider.TerraformVersion, meta.SDKVersionString())
 
-		keycloakClient, err := keycloak.NewKeycloakClient(ctx, url, basePath, clientId, clientSecret, realm, username, password, initialLogin, clientTimeout, rootCaCertificate, tlsInsecureSkipVerify, userAgent, redHatSSO, additionalHeaders)
+		keycloakClient, err := keycloak.NewKeycloakClient(ctx, &keycloak.KeycloakClientConfig{
+			Url:                   url,
+			BasePath:              basePath,
+			Realm:                 realm,
+			ClientId:              clientId,
+			ClientSecret:          clientSecret,
+			Username:              username,
+			Password:              password,
+			InitialLogin:          initialLogin,
+			ClientTimeout:         clientTimeout,
+			PageSize:              pageSize,
+			RootCaCertificate:     rootCaCertificate,
+			TlsClientCertificate:  tlsClientCertificate,
+			TlsClientPrivateKey:   tlsClientPrivateKey,
+			TlsInsecureSkipVerify: tlsInsecureSkipVerify,
+			UserAgent:             userAgent,
+			RedHatSSO:             redHatSSO,
+			AdditionalHeaders:     additionalHeaders,
+			RetryPolicy:           retryPolicy,
+			ClientAssertion:       clientAssertion,
+			ExternalToken:         externalToken,
+			SubjectToken:          subjectToken,
+		})
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -21,7 +21,8 @@ func TestAccKeycloakProvider_passwordGrant(t *testing.T) {
 		os.Unsetenv("KEYCLOAK_PASSWORD")
 	}()
 
-	provider := KeycloakProvider(keycloakClient)
+	// configure a provider of its own rather than sharing the client of the other tests, so the password grant is actually used
+	provider := KeycloakProvider()
 
 	clientId := acctest.RandomWithPrefix("tf-acc")
 
@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
 	"os"
 	"testing"
@@ -29,10 +31,19 @@ var requiredEnvironmentVariables = []string{
 func init() {
 	testCtx = context.Background()
 	userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", schema.Provider{}.TerraformVersion, meta.SDKVersionString())
-	keycloakClient, _ = keycloak.NewKeycloakClient(testCtx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), "", "", true, 5, "", false, userAgent, false, map[string]string{
-		"foo": "bar",
+	keycloakClient, _ = keycloak.NewKeycloakClient(testCtx, &keycloak.KeycloakClientConfig{
+		Url:           os.Getenv("KEYCLOAK_URL"),
+		ClientId:      os.Getenv("KEYCLOAK_CLIENT_ID"),
+		ClientSecret:  os.Getenv("KEYCLOAK_CLIENT_SECRET"),
+		Realm:         os.Getenv("KEYCLOAK_REALM"),
+		InitialLogin:  true,
+		ClientTimeout: 5,
+		UserAgent:     userAgent,
+		AdditionalHeaders: map[string]string{
+			"foo": "bar",
+		},
 	})
-	testAccProvider = KeycloakProvider(keycloakClient)
+	testAccProvider = testAccProviderWithClient(keycloakClient)
 	testAccProviderFactories = map[string]func() (*schema.Provider, error){
 		"keycloak": func() (*schema.Provider, error) {
 			return testAccProvider, nil
@@ -40,6 +51,17 @@ func init() {
 	}
 }
 
+// testAccProviderWithClient returns a provider which skips its own configuration and uses the given client instead,
+// so acceptance tests can share a single logged in client
+func testAccProviderWithClient(client *keycloak.KeycloakClient) *schema.Provider {
+	provider := KeycloakProvider()
+	provider.ConfigureContextFunc = func(_ context.Context, _ *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return client, nil
+	}
+
+	return provider
+}
+
 func TestMain(m *testing.M) {
 	testAccRealm = createTestRealm(testCtx)
 	testAccRealmTwo = createTestRealm(testCtx)
@@ -89,6 +111,29 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_eachConfigurationHasItsOwnClient(t *testing.T) {
+	t.Parallel()
+
+	primary := KeycloakProvider()
+	secondary := KeycloakProvider()
+
+	for provider, url := range map[*schema.Provider]string{primary: "https://primary.example.com", secondary: "https://secondary.example.com"} {
+		diags := provider.Configure(testCtx, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"url":           url,
+			"client_id":     "terraform",
+			"client_secret": "secret",
+			"initial_login": false,
+		}))
+		if diags.HasError() {
+			t.Fatalf("failed to configure provider: %v", diags)
+		}
+	}
+
+	if primary.Meta() == nil || primary.Meta() == secondary.Meta() {
+		t.Fatal("expected each provider configuration to create its own client")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	for _, requiredEnvironmentVariable := range requiredEnvironmentVariables {
 		if value := os.Getenv(requiredEnvironmentVariable); value == "" {
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakIdentityProviderPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakIdentityProviderPermissionsReconcile,
+		ReadContext:   resourceKeycloakIdentityProviderPermissionsRead,
+		DeleteContext: resourceKeycloakIdentityProviderPermissionsDelete,
+		UpdateContext: resourceKeycloakIdentityProviderPermissionsReconcile,
+		// This resource can be imported using {{realmId}}/{{providerAlias}}. The provider alias is displayed in the URL when editing it from the GUI
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakIdentityProviderPermissionsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"provider_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"authorization_resource_server_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource server id representing the realm management client on which this permission is managed",
+			},
+			"authorization_idp_resource_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resource id representing the identity provider, this automatically created by keycloak",
+			},
+			"token_exchange_scope": scopePermissionsSchema(),
+		},
+	}
+}
+
+func identityProviderPermissionsId(realmId, providerAlias string) string {
+	return fmt.Sprintf("%s/%s", realmId, providerAlias)
+}
+
+func resourceKeycloakIdentityProviderPermissionsReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	providerAlias := data.Get("provider_alias").(string)
+
+	// the existence of this resource implies that permissions are enabled for this identity provider.
+	err := keycloakClient.EnableIdentityProviderPermissions(ctx, realmId, providerAlias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	identityProviderPermissions, err := keycloakClient.GetIdentityProviderPermissions(ctx, realmId, providerAlias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	realmManagementClient, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, "realm-management")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tokenExchangeScope, ok := data.GetOk("token_exchange_scope"); ok {
+		permissionId, err := identityProviderPermissions.GetScopePermissionId("token-exchange")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = setOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, permissionId, tokenExchangeScope.(*schema.Set))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKeycloakIdentityProviderPermissionsRead(ctx, data, meta)
+}
+
+func resourceKeycloakIdentityProviderPermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	providerAlias := data.Get("provider_alias").(string)
+
+	identityProviderPermissions, err := keycloakClient.GetIdentityProviderPermissions(ctx, realmId, providerAlias)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	if !identityProviderPermissions.Enabled {
+		tflog.Warn(ctx, "Removing resource from state as it is no longer enabled", map[string]interface{}{
+			"id": data.Id(),
+		})
+		data.SetId("")
+		return nil
+	}
+
+	realmManagementClient, err := keycloakClient.GetOpenidClientByClientId(ctx, realmId, "realm-management")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(identityProviderPermissionsId(identityProviderPermissions.RealmId, identityProviderPermissions.ProviderAlias))
+	data.Set("realm_id", identityProviderPermissions.RealmId)
+	data.Set("provider_alias", identityProviderPermissions.ProviderAlias)
+	data.Set("enabled", identityProviderPermissions.Enabled)
+	data.Set("authorization_resource_server_id", realmManagementClient.Id)
+	data.Set("authorization_idp_resource_id", identityProviderPermissions.Resource)
+
+	permissionId, err := identityProviderPermissions.GetScopePermissionId("token-exchange")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tokenExchangeScope, err := getOpenidClientScopePermissionPolicy(ctx, keycloakClient, realmId, realmManagementClient.Id, permissionId); err == nil && tokenExchangeScope != nil {
+		data.Set("token_exchange_scope", []interface{}{tokenExchangeScope})
+	} else if err != nil {
+		return diag.FromErr(err)
+	} else {
+		data.Set("token_exchange_scope", nil)
+	}
+
+	return nil
+}
+
+func resourceKeycloakIdentityProviderPermissionsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	providerAlias := data.Get("provider_alias").(string)
+
+	return diag.FromErr(keycloakClient.DisableIdentityProviderPermissions(ctx, realmId, providerAlias))
+}
+
+func resourceKeycloakIdentityProviderPermissionsImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{providerAlias}}")
+	}
+	d.Set("realm_id", parts[0])
+	d.Set("provider_alias", parts[1])
+
+	d.SetId(identityProviderPermissionsId(parts[0], parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
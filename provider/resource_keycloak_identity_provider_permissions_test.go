@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakIdentityProviderPermissions_basic(t *testing.T) {
+	t.Parallel()
+
+	providerAlias := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakIdentityProviderPermissions_basic(providerAlias, clientId),
+				Check:  testAccCheckKeycloakIdentityProviderPermissionsExists("keycloak_identity_provider_permissions.my_permission"),
+			},
+			{
+				ResourceName:      "keycloak_identity_provider_permissions.my_permission",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testKeycloakIdentityProviderPermissionsDelete_basic(providerAlias, clientId),
+				Check:  testAccCheckKeycloakIdentityProviderPermissionsAreDisabled(providerAlias),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakIdentityProviderPermissionsExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		permissions, err := getIdentityProviderPermissionsFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+		authorizationResourceServerId := rs.Primary.Attributes["authorization_resource_server_id"]
+		tokenExchangeScopePolicyId := rs.Primary.Attributes["token_exchange_scope.0.policies.0"]
+		tokenExchangeScopeDescription := rs.Primary.Attributes["token_exchange_scope.0.description"]
+		tokenExchangeScopeDecisionStrategy := rs.Primary.Attributes["token_exchange_scope.0.decision_strategy"]
+
+		realmManagementClient, err := keycloakClient.GetOpenidClientByClientId(testCtx, permissions.RealmId, "realm-management")
+		if err != nil {
+			return err
+		}
+
+		if authorizationResourceServerId != realmManagementClient.Id {
+			return fmt.Errorf("computed authorizationResourceServerId %s was not equal to %s (the id of the realm-management client)", authorizationResourceServerId, realmManagementClient.Id)
+		}
+
+		permissionId, err := permissions.GetScopePermissionId("token-exchange")
+		if err != nil {
+			return err
+		}
+
+		tokenExchangePermission, err := keycloakClient.GetOpenidClientAuthorizationPermission(testCtx, permissions.RealmId, realmManagementClient.Id, permissionId)
+		if err != nil {
+			return err
+		}
+
+		if len(tokenExchangePermission.Policies) != 1 || tokenExchangeScopePolicyId != tokenExchangePermission.Policies[0] {
+			return fmt.Errorf("computed token exchange scope policy ID %s was not found in %v", tokenExchangeScopePolicyId, tokenExchangePermission.Policies)
+		}
+		if tokenExchangePermission.Description != tokenExchangeScopeDescription {
+			return fmt.Errorf("description %s was not equal to %s", tokenExchangePermission.Description, tokenExchangeScopeDescription)
+		}
+		if tokenExchangePermission.DecisionStrategy != tokenExchangeScopeDecisionStrategy {
+			return fmt.Errorf("decision strategy %s was not equal to %s", tokenExchangePermission.DecisionStrategy, tokenExchangeScopeDecisionStrategy)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakIdentityProviderPermissionsAreDisabled(providerAlias string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		permissions, err := keycloakClient.GetIdentityProviderPermissions(testCtx, testAccRealm.Realm, providerAlias)
+		if err != nil {
+			return fmt.Errorf("error getting identity provider permissions with realm id %s and provider alias %s: %s", testAccRealm.Realm, providerAlias, err)
+		}
+
+		if permissions.Enabled {
+			return fmt.Errorf("expected identity provider permissions in Keycloak to be disabled")
+		}
+
+		return nil
+	}
+}
+
+func getIdentityProviderPermissionsFromState(s *terraform.State, resourceName string) (*keycloak.IdentityProviderPermissions, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	realmId := rs.Primary.Attributes["realm_id"]
+	providerAlias := rs.Primary.Attributes["provider_alias"]
+
+	permissions, err := keycloakClient.GetIdentityProviderPermissions(testCtx, realmId, providerAlias)
+	if err != nil {
+		return nil, fmt.Errorf("error getting identity provider permissions with realm id %s and provider alias %s: %s", realmId, providerAlias, err)
+	}
+
+	return permissions, nil
+}
+
+func testKeycloakIdentityProviderPermissions_common(providerAlias, clientId string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_oidc_identity_provider" "my_idp" {
+	realm             = data.keycloak_realm.realm.id
+	alias             = "%s"
+	authorization_url = "http://localhost:8080/auth/realms/something/protocol/openid-connect/auth"
+	token_url         = "http://localhost:8080/auth/realms/something/protocol/openid-connect/token"
+	client_id         = "%s"
+	client_secret     = "secret"
+}
+
+data "keycloak_openid_client" "realm_management" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = "realm-management"
+}
+
+// enabling permissions on the realm-management client enables its authorization services, which the policy below needs
+resource "keycloak_openid_client_permissions" "realm_management_permission" {
+	realm_id  = data.keycloak_realm.realm.id
+	client_id = data.keycloak_openid_client.realm_management.id
+}
+
+resource "keycloak_openid_client" "webapp_client" {
+	realm_id              = data.keycloak_realm.realm.id
+	name                  = "webapp_client"
+	client_id             = "%s"
+	client_secret         = "secret"
+	access_type           = "CONFIDENTIAL"
+	standard_flow_enabled = true
+	valid_redirect_uris = [
+		"http://localhost:8080/*",
+	]
+}
+	`, testAccRealm.Realm, providerAlias, clientId, clientId+"-webapp")
+}
+
+func testKeycloakIdentityProviderPermissions_basic(providerAlias, clientId string) string {
+	return testKeycloakIdentityProviderPermissions_common(providerAlias, clientId) + `
+resource "keycloak_openid_client_client_policy" "webapp" {
+	realm_id           = data.keycloak_realm.realm.id
+	resource_server_id = data.keycloak_openid_client.realm_management.id
+
+	name    = "${keycloak_oidc_identity_provider.my_idp.alias}-webapp"
+	clients = [
+		keycloak_openid_client.webapp_client.id
+	]
+
+	logic             = "POSITIVE"
+	decision_strategy = "UNANIMOUS"
+
+	depends_on = [
+		keycloak_openid_client_permissions.realm_management_permission,
+	]
+}
+
+resource "keycloak_identity_provider_permissions" "my_permission" {
+	realm_id       = data.keycloak_realm.realm.id
+	provider_alias = keycloak_oidc_identity_provider.my_idp.alias
+
+	token_exchange_scope {
+		policies          = [
+			keycloak_openid_client_client_policy.webapp.id
+		]
+		description       = "token_exchange_scope"
+		decision_strategy = "AFFIRMATIVE"
+	}
+}
+	`
+}
+
+func testKeycloakIdentityProviderPermissionsDelete_basic(providerAlias, clientId string) string {
+	return testKeycloakIdentityProviderPermissions_common(providerAlias, clientId)
+}
@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+var (
+	keycloakKerberosUserFederationEditModes = []string{"READ_ONLY", "UNSYNCED"}
+)
+
+func resourceKeycloakKerberosUserFederation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakKerberosUserFederationCreate,
+		ReadContext:   resourceKeycloakKerberosUserFederationRead,
+		UpdateContext: resourceKeycloakKerberosUserFederationUpdate,
+		DeleteContext: resourceKeycloakKerberosUserFederationDelete,
+		// This resource can be imported using {{realm}}/{{provider_id}}.
+		// The Provider ID is displayed in the GUI when editing this provider
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakKerberosUserFederationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Display name of the provider when displayed in the console.",
+			},
+			"realm_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The realm this provider will provide user federation for.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "When false, this provider will not be used when performing queries for users.",
+			},
+			"priority": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Priority of this provider when looking up users. Lower values are first.",
+			},
+			"kerberos_realm": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the kerberos realm, e.g. FOO.LOCAL",
+			},
+			"server_principal": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The kerberos server principal, e.g. 'HTTP/host.foo.com@FOO.LOCAL'.",
+			},
+			"key_tab": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path to the kerberos keytab file on the server with credentials of the service principal.",
+			},
+			"debug": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, Krb5LoginModule debug logging is enabled on the server.",
+			},
+			"allow_password_authentication": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, users are allowed to authenticate with their kerberos username and password.",
+			},
+			"edit_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(keycloakKerberosUserFederationEditModes, false),
+				Description:  "READ_ONLY prevents password updates. UNSYNCED allows the password to be updated and stored in the Keycloak database. Must be set when `allow_password_authentication` is true, and can only be set then.",
+			},
+			"update_profile_first_login": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, users are asked to update their profile on their first login.",
+			},
+			"cache": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Settings regarding cache policy for this realm.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "DEFAULT",
+							ValidateFunc: validation.StringInSlice(keycloakUserFederationCachePolicies, false),
+						},
+						"max_lifespan": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressDurationStringDiff,
+							Description:      "Max lifespan of cache entry (duration string).",
+						},
+						"eviction_day": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      "-1",
+							ValidateFunc: validation.All(validation.IntAtLeast(0), validation.IntAtMost(6)),
+							Description:  "Day of the week the entry will become invalid on.",
+						},
+						"eviction_hour": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      "-1",
+							ValidateFunc: validation.All(validation.IntAtLeast(0), validation.IntAtMost(23)),
+							Description:  "Hour of day the entry will become invalid on.",
+						},
+						"eviction_minute": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      "-1",
+							ValidateFunc: validation.All(validation.IntAtLeast(0), validation.IntAtMost(59)),
+							Description:  "Minute of day the entry will become invalid on.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getKerberosUserFederationFromData(data *schema.ResourceData, realmInternalId string) *keycloak.KerberosUserFederation {
+	kerberosUserFederation := &keycloak.KerberosUserFederation{
+		Id:      data.Id(),
+		Name:    data.Get("name").(string),
+		RealmId: realmInternalId,
+
+		Enabled:  data.Get("enabled").(bool),
+		Priority: data.Get("priority").(int),
+
+		KerberosRealm:   data.Get("kerberos_realm").(string),
+		ServerPrincipal: data.Get("server_principal").(string),
+		KeyTab:          data.Get("key_tab").(string),
+		Debug:           data.Get("debug").(bool),
+
+		AllowPasswordAuthentication: data.Get("allow_password_authentication").(bool),
+		EditMode:                    data.Get("edit_mode").(string),
+		UpdateProfileFirstLogin:     data.Get("update_profile_first_login").(bool),
+	}
+
+	if cache, ok := data.GetOk("cache"); ok {
+		cache := cache.([]interface{})
+		cacheData := cache[0].(map[string]interface{})
+
+		evictionDay := cacheData["eviction_day"].(int)
+		evictionHour := cacheData["eviction_hour"].(int)
+		evictionMinute := cacheData["eviction_minute"].(int)
+
+		kerberosUserFederation.MaxLifespan = cacheData["max_lifespan"].(string)
+
+		kerberosUserFederation.EvictionDay = &evictionDay
+		kerberosUserFederation.EvictionHour = &evictionHour
+		kerberosUserFederation.EvictionMinute = &evictionMinute
+		kerberosUserFederation.CachePolicy = cacheData["policy"].(string)
+	}
+
+	return kerberosUserFederation
+}
+
+func setKerberosUserFederationData(data *schema.ResourceData, kerberos *keycloak.KerberosUserFederation, realmId string) {
+	data.SetId(kerberos.Id)
+
+	data.Set("name", kerberos.Name)
+	data.Set("realm_id", realmId)
+
+	data.Set("enabled", kerberos.Enabled)
+	data.Set("priority", kerberos.Priority)
+
+	data.Set("kerberos_realm", kerberos.KerberosRealm)
+	data.Set("server_principal", kerberos.ServerPrincipal)
+	data.Set("key_tab", kerberos.KeyTab)
+	data.Set("debug", kerberos.Debug)
+
+	data.Set("allow_password_authentication", kerberos.AllowPasswordAuthentication)
+	data.Set("edit_mode", kerberos.EditMode)
+	data.Set("update_profile_first_login", kerberos.UpdateProfileFirstLogin)
+
+	if _, ok := data.GetOk("cache"); ok {
+		cachePolicySettings := make(map[string]interface{})
+
+		if kerberos.MaxLifespan != "" {
+			cachePolicySettings["max_lifespan"] = kerberos.MaxLifespan
+		}
+
+		if kerberos.EvictionDay != nil {
+			cachePolicySettings["eviction_day"] = *kerberos.EvictionDay
+		}
+		if kerberos.EvictionHour != nil {
+			cachePolicySettings["eviction_hour"] = *kerberos.EvictionHour
+		}
+		if kerberos.EvictionMinute != nil {
+			cachePolicySettings["eviction_minute"] = *kerberos.EvictionMinute
+		}
+
+		cachePolicySettings["policy"] = kerberos.CachePolicy
+
+		data.Set("cache", []interface{}{cachePolicySettings})
+	}
+}
+
+func resourceKeycloakKerberosUserFederationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realm, err := keycloakClient.GetRealm(ctx, realmId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	kerberos := getKerberosUserFederationFromData(data, realm.Id)
+
+	err = keycloakClient.ValidateKerberosUserFederation(ctx, kerberos)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.NewKerberosUserFederation(ctx, realmId, kerberos)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setKerberosUserFederationData(data, kerberos, realmId)
+
+	return resourceKeycloakKerberosUserFederationRead(ctx, data, meta)
+}
+
+func resourceKeycloakKerberosUserFederationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	kerberos, err := keycloakClient.GetKerberosUserFederation(ctx, realmId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	setKerberosUserFederationData(data, kerberos, realmId)
+
+	return nil
+}
+
+func resourceKeycloakKerberosUserFederationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realm, err := keycloakClient.GetRealm(ctx, realmId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	kerberos := getKerberosUserFederationFromData(data, realm.Id)
+
+	err = keycloakClient.ValidateKerberosUserFederation(ctx, kerberos)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateKerberosUserFederation(ctx, realmId, kerberos)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setKerberosUserFederationData(data, kerberos, realmId)
+
+	return nil
+}
+
+func resourceKeycloakKerberosUserFederationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteKerberosUserFederation(ctx, realmId, id))
+}
+
+func resourceKeycloakKerberosUserFederationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realmId}}/{{userFederationId}}")
+	}
+
+	realmId := parts[0]
+	id := parts[1]
+
+	_, err := keycloakClient.GetKerberosUserFederation(ctx, realmId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.SetId(id)
+
+	diagnostics := resourceKeycloakKerberosUserFederationRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakKerberosUserFederation_basic(t *testing.T) {
+	t.Parallel()
+
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakKerberosUserFederation_basic(name),
+				Check:  testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+			},
+			{
+				ResourceName:        "keycloak_kerberos_user_federation.kerberos",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: testAccRealm.Realm + "/",
+			},
+		},
+	})
+}
+
+func TestAccKeycloakKerberosUserFederation_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+
+	var kerberos = &keycloak.KerberosUserFederation{}
+
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakKerberosUserFederation_basic(name),
+				Check:  testAccCheckKeycloakKerberosUserFederationFetch("keycloak_kerberos_user_federation.kerberos", kerberos),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteKerberosUserFederation(testCtx, kerberos.RealmId, kerberos.Id)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakKerberosUserFederation_basic(name),
+				Check:  testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakKerberosUserFederation_basicUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	firstEnabled := randomBool()
+
+	firstKerberos := &keycloak.KerberosUserFederation{
+		Name:                        acctest.RandString(10),
+		Enabled:                     firstEnabled,
+		Priority:                    acctest.RandIntRange(0, 100),
+		KerberosRealm:               acctest.RandString(10),
+		ServerPrincipal:             acctest.RandString(10),
+		KeyTab:                      acctest.RandString(10),
+		Debug:                       randomBool(),
+		AllowPasswordAuthentication: true,
+		EditMode:                    randomStringInSlice(keycloakKerberosUserFederationEditModes),
+		UpdateProfileFirstLogin:     randomBool(),
+	}
+
+	secondKerberos := &keycloak.KerberosUserFederation{
+		Name:                        acctest.RandString(10),
+		Enabled:                     !firstEnabled,
+		Priority:                    acctest.RandIntRange(0, 100),
+		KerberosRealm:               acctest.RandString(10),
+		ServerPrincipal:             acctest.RandString(10),
+		KeyTab:                      acctest.RandString(10),
+		Debug:                       randomBool(),
+		AllowPasswordAuthentication: false,
+		UpdateProfileFirstLogin:     randomBool(),
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakKerberosUserFederation_basicFromInterface(firstKerberos),
+				Check:  testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+			},
+			{
+				Config: testKeycloakKerberosUserFederation_basicFromInterface(secondKerberos),
+				Check:  testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakKerberosUserFederation_cachePolicy(t *testing.T) {
+	t.Parallel()
+
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakKerberosUserFederation_cachePolicy(name, "EVICT_DAILY"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakKerberosUserFederationExists("keycloak_kerberos_user_federation.kerberos"),
+					resource.TestCheckResourceAttr("keycloak_kerberos_user_federation.kerberos", "cache.0.policy", "EVICT_DAILY"),
+					resource.TestCheckResourceAttr("keycloak_kerberos_user_federation.kerberos", "cache.0.eviction_hour", "5"),
+					resource.TestCheckResourceAttr("keycloak_kerberos_user_federation.kerberos", "cache.0.eviction_minute", "30"),
+				),
+			},
+			{
+				Config: testKeycloakKerberosUserFederation_cachePolicy(name, "NO_CACHE"),
+				Check:  resource.TestCheckResourceAttr("keycloak_kerberos_user_federation.kerberos", "cache.0.policy", "NO_CACHE"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakKerberosUserFederation_editModeValidation(t *testing.T) {
+	t.Parallel()
+
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakKerberosUserFederationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakKerberosUserFederation_editModeWithoutPasswordAuthentication(name),
+				ExpectError: regexp.MustCompile("validation error: EditMode can only be set when AllowPasswordAuthentication is true"),
+			},
+			{
+				Config:      testKeycloakKerberosUserFederation_passwordAuthenticationWithoutEditMode(name),
+				ExpectError: regexp.MustCompile("validation error: EditMode must be set when AllowPasswordAuthentication is true"),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakKerberosUserFederationExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getKerberosUserFederationFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakKerberosUserFederationFetch(resourceName string, kerberos *keycloak.KerberosUserFederation) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedKerberos, err := getKerberosUserFederationFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		kerberos.Id = fetchedKerberos.Id
+		kerberos.RealmId = fetchedKerberos.RealmId
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakKerberosUserFederationDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_kerberos_user_federation" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm_id"]
+
+			kerberos, _ := keycloakClient.GetKerberosUserFederation(testCtx, realm, id)
+			if kerberos != nil {
+				return fmt.Errorf("kerberos user federation with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getKerberosUserFederationFromState(s *terraform.State, resourceName string) (*keycloak.KerberosUserFederation, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+
+	kerberos, err := keycloakClient.GetKerberosUserFederation(testCtx, realm, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting kerberos user federation with id %s: %s", id, err)
+	}
+
+	return kerberos, nil
+}
+
+func testKeycloakKerberosUserFederation_basic(name string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_kerberos_user_federation" "kerberos" {
+	name             = "%s"
+	realm_id         = data.keycloak_realm.realm.id
+
+	kerberos_realm   = "FOO.LOCAL"
+	server_principal = "HTTP/host.foo.com@FOO.LOCAL"
+	key_tab          = "/etc/host.keytab"
+}
+	`, testAccRealm.Realm, name)
+}
+
+func testKeycloakKerberosUserFederation_basicFromInterface(kerberos *keycloak.KerberosUserFederation) string {
+	editMode := ""
+	if kerberos.EditMode != "" {
+		editMode = fmt.Sprintf(`edit_mode                     = "%s"`, kerberos.EditMode)
+	}
+
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_kerberos_user_federation" "kerberos" {
+	name                          = "%s"
+	realm_id                      = data.keycloak_realm.realm.id
+
+	enabled                       = %t
+	priority                      = %d
+
+	kerberos_realm                = "%s"
+	server_principal              = "%s"
+	key_tab                       = "%s"
+	debug                         = %t
+
+	allow_password_authentication = %t
+	%s
+	update_profile_first_login    = %t
+}
+	`, testAccRealm.Realm, kerberos.Name, kerberos.Enabled, kerberos.Priority, kerberos.KerberosRealm, kerberos.ServerPrincipal, kerberos.KeyTab, kerberos.Debug, kerberos.AllowPasswordAuthentication, editMode, kerberos.UpdateProfileFirstLogin)
+}
+
+func testKeycloakKerberosUserFederation_cachePolicy(name, cachePolicy string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_kerberos_user_federation" "kerberos" {
+	name             = "%s"
+	realm_id         = data.keycloak_realm.realm.id
+
+	kerberos_realm   = "FOO.LOCAL"
+	server_principal = "HTTP/host.foo.com@FOO.LOCAL"
+	key_tab          = "/etc/host.keytab"
+
+	cache {
+		policy          = "%s"
+		eviction_hour   = 5
+		eviction_minute = 30
+	}
+}
+	`, testAccRealm.Realm, name, cachePolicy)
+}
+
+func testKeycloakKerberosUserFederation_editModeWithoutPasswordAuthentication(name string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_kerberos_user_federation" "kerberos" {
+	name             = "%s"
+	realm_id         = data.keycloak_realm.realm.id
+
+	kerberos_realm   = "FOO.LOCAL"
+	server_principal = "HTTP/host.foo.com@FOO.LOCAL"
+	key_tab          = "/etc/host.keytab"
+
+	edit_mode        = "UNSYNCED"
+}
+	`, testAccRealm.Realm, name)
+}
+
+func testKeycloakKerberosUserFederation_passwordAuthenticationWithoutEditMode(name string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_kerberos_user_federation" "kerberos" {
+	name             = "%s"
+	realm_id         = data.keycloak_realm.realm.id
+
+	kerberos_realm   = "FOO.LOCAL"
+	server_principal = "HTTP/host.foo.com@FOO.LOCAL"
+	key_tab          = "/etc/host.keytab"
+
+	allow_password_authentication = true
+}
+	`, testAccRealm.Realm, name)
+}
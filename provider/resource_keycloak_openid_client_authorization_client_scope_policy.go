@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOpenidClientAuthorizationClientScopePolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOpenidClientAuthorizationClientScopePolicyCreate,
+		ReadContext:   resourceKeycloakOpenidClientAuthorizationClientScopePolicyRead,
+		DeleteContext: resourceKeycloakOpenidClientAuthorizationClientScopePolicyDelete,
+		UpdateContext: resourceKeycloakOpenidClientAuthorizationClientScopePolicyUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: genericResourcePolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"decision_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"logic": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(keycloakPolicyLogicTypes, false),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"scope": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"required": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getOpenidClientAuthorizationClientScopePolicyResourceFromData(data *schema.ResourceData) *keycloak.OpenidClientAuthorizationClientScopePolicy {
+	var clientScopes []keycloak.OpenidClientAuthorizationClientScope
+	if v, ok := data.Get("scope").(*schema.Set); ok {
+		for _, scope := range v.List() {
+			scopeMap := scope.(map[string]interface{})
+			clientScopes = append(clientScopes, keycloak.OpenidClientAuthorizationClientScope{
+				Id:       scopeMap["id"].(string),
+				Required: scopeMap["required"].(bool),
+			})
+		}
+	}
+
+	resource := keycloak.OpenidClientAuthorizationClientScopePolicy{
+		Id:               data.Id(),
+		ResourceServerId: data.Get("resource_server_id").(string),
+		RealmId:          data.Get("realm_id").(string),
+		DecisionStrategy: data.Get("decision_strategy").(string),
+		Logic:            data.Get("logic").(string),
+		Name:             data.Get("name").(string),
+		Type:             "client-scope",
+		ClientScopes:     clientScopes,
+		Description:      data.Get("description").(string),
+	}
+
+	return &resource
+}
+
+func setOpenidClientAuthorizationClientScopePolicyResourceData(data *schema.ResourceData, policy *keycloak.OpenidClientAuthorizationClientScopePolicy) {
+	data.SetId(policy.Id)
+
+	data.Set("resource_server_id", policy.ResourceServerId)
+	data.Set("realm_id", policy.RealmId)
+	data.Set("name", policy.Name)
+	data.Set("decision_strategy", policy.DecisionStrategy)
+	data.Set("logic", policy.Logic)
+	data.Set("description", policy.Description)
+
+	var clientScopes []interface{}
+	for _, s := range policy.ClientScopes {
+		clientScopes = append(clientScopes, map[string]interface{}{
+			"id":       s.Id,
+			"required": s.Required,
+		})
+	}
+
+	data.Set("scope", clientScopes)
+}
+
+func resourceKeycloakOpenidClientAuthorizationClientScopePolicyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	resource := getOpenidClientAuthorizationClientScopePolicyResourceFromData(data)
+
+	err := keycloakClient.NewOpenidClientAuthorizationClientScopePolicy(ctx, resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setOpenidClientAuthorizationClientScopePolicyResourceData(data, resource)
+
+	return resourceKeycloakOpenidClientAuthorizationClientScopePolicyRead(ctx, data, meta)
+}
+
+func resourceKeycloakOpenidClientAuthorizationClientScopePolicyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+	id := data.Id()
+
+	resource, err := keycloakClient.GetOpenidClientAuthorizationClientScopePolicy(ctx, realmId, resourceServerId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	setOpenidClientAuthorizationClientScopePolicyResourceData(data, resource)
+
+	return nil
+}
+
+func resourceKeycloakOpenidClientAuthorizationClientScopePolicyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	resource := getOpenidClientAuthorizationClientScopePolicyResourceFromData(data)
+
+	err := keycloakClient.UpdateOpenidClientAuthorizationClientScopePolicy(ctx, resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setOpenidClientAuthorizationClientScopePolicyResourceData(data, resource)
+
+	return nil
+}
+
+func resourceKeycloakOpenidClientAuthorizationClientScopePolicyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteOpenidClientAuthorizationClientScopePolicy(ctx, realmId, resourceServerId, id))
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOpenidClientAuthorizationRegexPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOpenidClientAuthorizationRegexPolicyCreate,
+		ReadContext:   resourceKeycloakOpenidClientAuthorizationRegexPolicyRead,
+		DeleteContext: resourceKeycloakOpenidClientAuthorizationRegexPolicyDelete,
+		UpdateContext: resourceKeycloakOpenidClientAuthorizationRegexPolicyUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: genericResourcePolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"decision_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"logic": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(keycloakPolicyLogicTypes, false),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"target_claim": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the claim in the token, or of the context attribute, that is matched against the pattern. Nested claims can be referenced using dots, such as address.country.",
+			},
+			"pattern": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The Java regular expression the value of the target claim is matched against.",
+			},
+			"target_context_attributes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, the target claim is looked up in the attributes of the evaluation context instead of the identity's claims.",
+			},
+		},
+	}
+}
+
+func getOpenidClientAuthorizationRegexPolicyResourceFromData(data *schema.ResourceData) *keycloak.OpenidClientAuthorizationRegexPolicy {
+	resource := keycloak.OpenidClientAuthorizationRegexPolicy{
+		Id:                      data.Id(),
+		ResourceServerId:        data.Get("resource_server_id").(string),
+		RealmId:                 data.Get("realm_id").(string),
+		DecisionStrategy:        data.Get("decision_strategy").(string),
+		Logic:                   data.Get("logic").(string),
+		Name:                    data.Get("name").(string),
+		Type:                    "regex",
+		TargetClaim:             data.Get("target_claim").(string),
+		Pattern:                 data.Get("pattern").(string),
+		TargetContextAttributes: data.Get("target_context_attributes").(bool),
+		Description:             data.Get("description").(string),
+	}
+
+	return &resource
+}
+
+func setOpenidClientAuthorizationRegexPolicyResourceData(data *schema.ResourceData, policy *keycloak.OpenidClientAuthorizationRegexPolicy) {
+	data.SetId(policy.Id)
+
+	data.Set("resource_server_id", policy.ResourceServerId)
+	data.Set("realm_id", policy.RealmId)
+	data.Set("name", policy.Name)
+	data.Set("decision_strategy", policy.DecisionStrategy)
+	data.Set("logic", policy.Logic)
+	data.Set("description", policy.Description)
+	data.Set("target_claim", policy.TargetClaim)
+	data.Set("pattern", policy.Pattern)
+	data.Set("target_context_attributes", policy.TargetContextAttributes)
+}
+
+func resourceKeycloakOpenidClientAuthorizationRegexPolicyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	resource := getOpenidClientAuthorizationRegexPolicyResourceFromData(data)
+
+	err := keycloakClient.NewOpenidClientAuthorizationRegexPolicy(ctx, resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setOpenidClientAuthorizationRegexPolicyResourceData(data, resource)
+
+	return resourceKeycloakOpenidClientAuthorizationRegexPolicyRead(ctx, data, meta)
+}
+
+func resourceKeycloakOpenidClientAuthorizationRegexPolicyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+	id := data.Id()
+
+	resource, err := keycloakClient.GetOpenidClientAuthorizationRegexPolicy(ctx, realmId, resourceServerId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	setOpenidClientAuthorizationRegexPolicyResourceData(data, resource)
+
+	return nil
+}
+
+func resourceKeycloakOpenidClientAuthorizationRegexPolicyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	resource := getOpenidClientAuthorizationRegexPolicyResourceFromData(data)
+
+	err := keycloakClient.UpdateOpenidClientAuthorizationRegexPolicy(ctx, resource)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setOpenidClientAuthorizationRegexPolicyResourceData(data, resource)
+
+	return nil
+}
+
+func resourceKeycloakOpenidClientAuthorizationRegexPolicyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteOpenidClientAuthorizationRegexPolicy(ctx, realmId, resourceServerId, id))
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOpenidClientAuthorizationSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOpenidClientAuthorizationSettingsCreate,
+		ReadContext:   resourceKeycloakOpenidClientAuthorizationSettingsRead,
+		DeleteContext: resourceKeycloakOpenidClientAuthorizationSettingsDelete,
+		UpdateContext: resourceKeycloakOpenidClientAuthorizationSettingsUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOpenidClientAuthorizationSettingsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"resource_server_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"settings_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressAuthorizationSettingsDiff,
+				Description:      "The resource server representation, as exported from the Authorization tab of the client",
+			},
+		},
+	}
+}
+
+func suppressAuthorizationSettingsDiff(_, old, new string, _ *schema.ResourceData) bool {
+	oldNormalized, err := keycloak.NormalizeOpenidClientAuthorizationSettings(old)
+	if err != nil {
+		return false
+	}
+
+	newNormalized, err := keycloak.NormalizeOpenidClientAuthorizationSettings(new)
+	if err != nil {
+		return false
+	}
+
+	return oldNormalized == newNormalized
+}
+
+func openidClientAuthorizationSettingsId(realmId, resourceServerId string) string {
+	return fmt.Sprintf("%s/%s", realmId, resourceServerId)
+}
+
+func resourceKeycloakOpenidClientAuthorizationSettingsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+
+	err := keycloakClient.ImportOpenidClientAuthorizationSettings(ctx, realmId, resourceServerId, data.Get("settings_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(openidClientAuthorizationSettingsId(realmId, resourceServerId))
+
+	return resourceKeycloakOpenidClientAuthorizationSettingsRead(ctx, data, meta)
+}
+
+func resourceKeycloakOpenidClientAuthorizationSettingsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+
+	// only the entities declared by this resource are read, so entities managed by other resources don't show up as drift
+	settings, err := keycloakClient.GetOpenidClientAuthorizationSettings(ctx, realmId, resourceServerId, data.Get("settings_json").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	data.Set("settings_json", settings)
+
+	return nil
+}
+
+func resourceKeycloakOpenidClientAuthorizationSettingsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+	oldSettings, newSettings := data.GetChange("settings_json")
+
+	err := keycloakClient.ImportOpenidClientAuthorizationSettings(ctx, realmId, resourceServerId, newSettings.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// importing never removes anything, so entities which are no longer declared have to be deleted
+	err = keycloakClient.DeleteOpenidClientAuthorizationSettingsEntities(ctx, realmId, resourceServerId, oldSettings.(string), newSettings.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakOpenidClientAuthorizationSettingsRead(ctx, data, meta)
+}
+
+func resourceKeycloakOpenidClientAuthorizationSettingsDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	resourceServerId := data.Get("resource_server_id").(string)
+
+	err := keycloakClient.DeleteOpenidClientAuthorizationSettingsEntities(ctx, realmId, resourceServerId, data.Get("settings_json").(string), "")
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOpenidClientAuthorizationSettingsImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{resourceServerId}}")
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("resource_server_id", parts[1])
+
+	d.SetId(openidClientAuthorizationSettingsId(parts[0], parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
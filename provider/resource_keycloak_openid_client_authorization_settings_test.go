@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakOpenidClientAuthorizationSettings_basic(t *testing.T) {
+	t.Parallel()
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOpenidClientAuthorizationSettingsDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOpenidClientAuthorizationSettings_basic(clientId, []string{"read", "write"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOpenidClientAuthorizationSettingsHasScope("keycloak_openid_client_authorization_settings.test", "read", true),
+					testAccCheckKeycloakOpenidClientAuthorizationSettingsHasScope("keycloak_openid_client_authorization_settings.test", "write", true),
+				),
+			},
+			{
+				Config: testKeycloakOpenidClientAuthorizationSettings_basic(clientId, []string{"read"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOpenidClientAuthorizationSettingsHasScope("keycloak_openid_client_authorization_settings.test", "read", true),
+					testAccCheckKeycloakOpenidClientAuthorizationSettingsHasScope("keycloak_openid_client_authorization_settings.test", "write", false),
+				),
+			},
+		},
+	})
+}
+
+func getKeycloakOpenidClientAuthorizationSettingsFromState(s *terraform.State, resourceName string) (string, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return "", fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	realmId := rs.Primary.Attributes["realm_id"]
+	resourceServerId := rs.Primary.Attributes["resource_server_id"]
+
+	settings, err := keycloakClient.GetOpenidClientAuthorizationSettings(testCtx, realmId, resourceServerId, "")
+	if err != nil {
+		return "", fmt.Errorf("error getting authorization settings for resource server %s: %s", resourceServerId, err)
+	}
+
+	return settings, nil
+}
+
+func testAccCheckKeycloakOpenidClientAuthorizationSettingsHasScope(resourceName, scope string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		settings, err := getKeycloakOpenidClientAuthorizationSettingsFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		if found := strings.Contains(settings, fmt.Sprintf(`{"name":"%s"}`, scope)); found != expected {
+			return fmt.Errorf("expected authorization scope %s to exist: %t, got %t", scope, expected, found)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakOpenidClientAuthorizationSettingsDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_openid_client_authorization_settings" {
+				continue
+			}
+
+			realmId := rs.Primary.Attributes["realm_id"]
+			resourceServerId := rs.Primary.Attributes["resource_server_id"]
+
+			// the client is destroyed along with its resource server, which is the expected outcome
+			settings, err := keycloakClient.GetOpenidClientAuthorizationSettings(testCtx, realmId, resourceServerId, "")
+			if err == nil && strings.Contains(settings, `"name":"documents"`) {
+				return fmt.Errorf("authorization resource documents still exists on resource server %s", resourceServerId)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOpenidClientAuthorizationSettings_basic(clientId string, scopes []string) string {
+	var scopeNames []string
+	for _, scope := range scopes {
+		scopeNames = append(scopeNames, fmt.Sprintf(`{ name = "%s" }`, scope))
+	}
+
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "test" {
+	client_id                = "%s"
+	realm_id                 = data.keycloak_realm.realm.id
+	access_type              = "CONFIDENTIAL"
+	service_accounts_enabled = true
+
+	authorization {
+		policy_enforcement_mode = "ENFORCING"
+	}
+}
+
+resource "keycloak_openid_client_authorization_settings" "test" {
+	realm_id           = data.keycloak_realm.realm.id
+	resource_server_id = keycloak_openid_client.test.resource_server_id
+
+	settings_json = jsonencode({
+		policyEnforcementMode = "ENFORCING"
+		scopes                = [%s]
+		resources = [
+			{
+				name   = "documents"
+				uris   = ["/documents/*"]
+				scopes = [%s]
+			}
+		]
+	})
+}
+	`, testAccRealm.Realm, clientId, strings.Join(scopeNames, ", "), strings.Join(scopeNames, ", "))
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationCreate,
+		ReadContext:   resourceKeycloakOrganizationRead,
+		UpdateContext: resourceKeycloakOrganizationUpdate,
+		DeleteContext: resourceKeycloakOrganizationDelete,
+		// This resource can be imported using {{realm}}/{{organizationId}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"alias": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The alias of the organization, which is used to refer to it in tokens. Requires Keycloak 26 or later.",
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"redirect_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL users are redirected to after they accept an invitation to the organization. Requires Keycloak 26 or later.",
+			},
+			"domain": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"verified": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"attributes": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func getOrganizationFromData(data *schema.ResourceData) *keycloak.Organization {
+	domains := make([]*keycloak.OrganizationDomain, 0)
+	for _, v := range data.Get("domain").(*schema.Set).List() {
+		domain := v.(map[string]interface{})
+
+		domains = append(domains, &keycloak.OrganizationDomain{
+			Name:     domain["name"].(string),
+			Verified: domain["verified"].(bool),
+		})
+	}
+
+	attributes := map[string][]string{}
+	if v, ok := data.GetOk("attributes"); ok {
+		for key, value := range v.(map[string]interface{}) {
+			attributes[key] = strings.Split(value.(string), MULTIVALUE_ATTRIBUTE_SEPARATOR)
+		}
+	}
+
+	return &keycloak.Organization{
+		Id:          data.Id(),
+		RealmId:     data.Get("realm_id").(string),
+		Name:        data.Get("name").(string),
+		Alias:       data.Get("alias").(string),
+		Enabled:     data.Get("enabled").(bool),
+		Description: data.Get("description").(string),
+		RedirectUrl: data.Get("redirect_url").(string),
+		Attributes:  attributes,
+		Domains:     domains,
+	}
+}
+
+func setOrganizationData(data *schema.ResourceData, organization *keycloak.Organization) {
+	domains := make([]interface{}, 0, len(organization.Domains))
+	for _, domain := range organization.Domains {
+		domains = append(domains, map[string]interface{}{
+			"name":     domain.Name,
+			"verified": domain.Verified,
+		})
+	}
+
+	attributes := map[string]string{}
+	for k, v := range organization.Attributes {
+		attributes[k] = strings.Join(v, MULTIVALUE_ATTRIBUTE_SEPARATOR)
+	}
+
+	data.SetId(organization.Id)
+
+	data.Set("realm_id", organization.RealmId)
+	data.Set("name", organization.Name)
+	data.Set("alias", organization.Alias)
+	data.Set("enabled", organization.Enabled)
+	data.Set("description", organization.Description)
+	data.Set("redirect_url", organization.RedirectUrl)
+	data.Set("domain", domains)
+	data.Set("attributes", attributes)
+}
+
+func resourceKeycloakOrganizationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	organization := getOrganizationFromData(data)
+
+	err := keycloakClient.NewOrganization(ctx, organization)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setOrganizationData(data, organization)
+
+	return resourceKeycloakOrganizationRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	organization, err := keycloakClient.GetOrganization(ctx, realmId, id)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	setOrganizationData(data, organization)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	organization := getOrganizationFromData(data)
+
+	err := keycloakClient.UpdateOrganization(ctx, organization)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakOrganizationRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	id := data.Id()
+
+	return diag.FromErr(keycloakClient.DeleteOrganization(ctx, realmId, id))
+}
+
+func resourceKeycloakOrganizationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{organizationId}}")
+	}
+
+	organization, err := keycloakClient.GetOrganization(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	setOrganizationData(d, organization)
+
+	return []*schema.ResourceData{d}, nil
+}
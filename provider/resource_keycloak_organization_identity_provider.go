@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationIdentityProviderCreate,
+		ReadContext:   resourceKeycloakOrganizationIdentityProviderRead,
+		DeleteContext: resourceKeycloakOrganizationIdentityProviderDelete,
+		// This resource can be imported using {{realm}}/{{organizationId}}/{{identityProviderAlias}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationIdentityProviderImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"identity_provider_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func organizationIdentityProviderId(realmId, organizationId, alias string) string {
+	return fmt.Sprintf("%s/%s/%s", realmId, organizationId, alias)
+}
+
+func resourceKeycloakOrganizationIdentityProviderCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	alias := data.Get("identity_provider_alias").(string)
+
+	err := keycloakClient.AddOrganizationIdentityProvider(ctx, realmId, organizationId, alias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(organizationIdentityProviderId(realmId, organizationId, alias))
+
+	return resourceKeycloakOrganizationIdentityProviderRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationIdentityProviderRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	alias := data.Get("identity_provider_alias").(string)
+
+	_, err := keycloakClient.GetOrganizationIdentityProvider(ctx, realmId, organizationId, alias)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationIdentityProviderDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	alias := data.Get("identity_provider_alias").(string)
+
+	err := keycloakClient.RemoveOrganizationIdentityProvider(ctx, realmId, organizationId, alias)
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationIdentityProviderImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{organizationId}}/{{identityProviderAlias}}")
+	}
+
+	_, err := keycloakClient.GetOrganizationIdentityProvider(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("organization_id", parts[1])
+	d.Set("identity_provider_alias", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakOrganizationIdentityProvider_basic(t *testing.T) {
+	skipIfOrganizationsAreNotSupported(t)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationIdentityProvider_basic(realmName, organizationName, alias),
+				Check:  testAccCheckKeycloakOrganizationIdentityProviderExists("keycloak_organization_identity_provider.link"),
+			},
+			{
+				ResourceName:      "keycloak_organization_identity_provider.link",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakOrganizationIdentityProviderExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm_id"]
+		organizationId := rs.Primary.Attributes["organization_id"]
+		alias := rs.Primary.Attributes["identity_provider_alias"]
+
+		_, err := keycloakClient.GetOrganizationIdentityProvider(testCtx, realm, organizationId, alias)
+		if err != nil {
+			return fmt.Errorf("error getting identity provider %s of organization %s: %s", alias, organizationId, err)
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOrganizationIdentityProvider_basic(realm, organizationName, alias string) string {
+	return testKeycloakOrganization_basic(realm, organizationName, "example.com") + fmt.Sprintf(`
+resource "keycloak_oidc_identity_provider" "oidc" {
+	realm             = keycloak_realm.realm.id
+	alias             = "%s"
+	authorization_url = "https://example.com/auth"
+	token_url         = "https://example.com/token"
+	client_id         = "example_id"
+	client_secret     = "example_token"
+
+	extra_config = {
+		"kc.org.domain" = "example.com"
+	}
+}
+
+resource "keycloak_organization_identity_provider" "link" {
+	realm_id                = keycloak_realm.realm.id
+	organization_id         = keycloak_organization.organization.id
+	identity_provider_alias = keycloak_oidc_identity_provider.oidc.alias
+}
+	`, alias)
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationMembershipCreate,
+		ReadContext:   resourceKeycloakOrganizationMembershipRead,
+		DeleteContext: resourceKeycloakOrganizationMembershipDelete,
+		// This resource can be imported using {{realm}}/{{organizationId}}/{{userId}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationMembershipImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func organizationMembershipId(realmId, organizationId, userId string) string {
+	return fmt.Sprintf("%s/%s/%s", realmId, organizationId, userId)
+}
+
+func resourceKeycloakOrganizationMembershipCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	userId := data.Get("user_id").(string)
+
+	err := keycloakClient.AddOrganizationMember(ctx, realmId, organizationId, userId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(organizationMembershipId(realmId, organizationId, userId))
+
+	return resourceKeycloakOrganizationMembershipRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationMembershipRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	userId := data.Get("user_id").(string)
+
+	_, err := keycloakClient.GetOrganizationMember(ctx, realmId, organizationId, userId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMembershipDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	organizationId := data.Get("organization_id").(string)
+	userId := data.Get("user_id").(string)
+
+	err := keycloakClient.RemoveOrganizationMember(ctx, realmId, organizationId, userId)
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMembershipImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{organizationId}}/{{userId}}")
+	}
+
+	_, err := keycloakClient.GetOrganizationMember(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("organization_id", parts[1])
+	d.Set("user_id", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakOrganizationMembership_basic(t *testing.T) {
+	skipIfOrganizationsAreNotSupported(t)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganizationMembership_basic(realmName, organizationName, username),
+				Check:  testAccCheckKeycloakOrganizationMembershipExists("keycloak_organization_membership.membership"),
+			},
+			{
+				ResourceName:      "keycloak_organization_membership.membership",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// removing the membership leaves the user alone
+				Config: testKeycloakOrganization_basic(realmName, organizationName, "example.com") + testKeycloakOrganizationMembership_user(username),
+				Check: func(s *terraform.State) error {
+					organization, err := getOrganizationFromState(s, "keycloak_organization.organization")
+					if err != nil {
+						return err
+					}
+
+					user, err := getUserFromState(s, "keycloak_user.user")
+					if err != nil {
+						return err
+					}
+
+					_, err = keycloakClient.GetOrganizationMember(testCtx, realmName, organization.Id, user.Id)
+					if !keycloak.ErrorIs404(err) {
+						return fmt.Errorf("expected user %s to no longer be a member of organization %s, got %v", username, organizationName, err)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakOrganizationMembershipExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm_id"]
+		organizationId := rs.Primary.Attributes["organization_id"]
+		userId := rs.Primary.Attributes["user_id"]
+
+		_, err := keycloakClient.GetOrganizationMember(testCtx, realm, organizationId, userId)
+		if err != nil {
+			return fmt.Errorf("error getting member %s of organization %s: %s", userId, organizationId, err)
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakOrganizationMembership_user(username string) string {
+	return fmt.Sprintf(`
+resource "keycloak_user" "user" {
+	realm_id = keycloak_realm.realm.id
+	username = "%s"
+	email    = "%s@example.com"
+}
+	`, username, username)
+}
+
+func testKeycloakOrganizationMembership_basic(realm, organizationName, username string) string {
+	return testKeycloakOrganization_basic(realm, organizationName, "example.com") + testKeycloakOrganizationMembership_user(username) + `
+resource "keycloak_organization_membership" "membership" {
+	realm_id        = keycloak_realm.realm.id
+	organization_id = keycloak_organization.organization.id
+	user_id         = keycloak_user.user.id
+}
+	`
+}
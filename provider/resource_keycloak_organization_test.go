@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func skipIfOrganizationsAreNotSupported(t *testing.T) {
+	ok, err := keycloakClient.VersionIsGreaterThanOrEqualTo(testCtx, keycloak.Version_25)
+	if err != nil {
+		t.Errorf("error checking keycloak version: %v", err)
+	}
+
+	if !ok {
+		t.Skip("keycloak server version does not support organizations, skipping...")
+	}
+}
+
+func TestAccKeycloakOrganization_basic(t *testing.T) {
+	skipIfOrganizationsAreNotSupported(t)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	organizationName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakOrganizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakOrganization_basic(realmName, organizationName, "example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakOrganizationExists("keycloak_organization.organization"),
+					resource.TestCheckResourceAttr("keycloak_organization.organization", "domain.#", "1"),
+					resource.TestCheckResourceAttr("keycloak_organization.organization", "attributes.tier", "gold"),
+					resource.TestCheckResourceAttr("keycloak_realm.realm", "organizations_enabled", "true"),
+				),
+			},
+			{
+				Config: testKeycloakOrganization_basic(realmName, organizationName+"-renamed", "example.org"),
+				Check: func(s *terraform.State) error {
+					organization, err := getOrganizationFromState(s, "keycloak_organization.organization")
+					if err != nil {
+						return err
+					}
+
+					if organization.Name != organizationName+"-renamed" {
+						return fmt.Errorf("expected organization to be renamed to %s-renamed, but was %s", organizationName, organization.Name)
+					}
+
+					if len(organization.Domains) != 1 || organization.Domains[0].Name != "example.org" {
+						return fmt.Errorf("expected organization to have the domain example.org, but had %v", organization.Domains)
+					}
+
+					return nil
+				},
+			},
+			{
+				ResourceName:        "keycloak_organization.organization",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: realmName + "/",
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakOrganizationExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getOrganizationFromState(s, resourceName)
+
+		return err
+	}
+}
+
+func testAccCheckKeycloakOrganizationDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_organization" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm_id"]
+
+			organization, _ := keycloakClient.GetOrganization(testCtx, realm, id)
+			if organization != nil {
+				return fmt.Errorf("organization with id %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getOrganizationFromState(s *terraform.State, resourceName string) (*keycloak.Organization, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	id := rs.Primary.ID
+	realm := rs.Primary.Attributes["realm_id"]
+
+	organization, err := keycloakClient.GetOrganization(testCtx, realm, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting organization with id %s: %s", id, err)
+	}
+
+	return organization, nil
+}
+
+func testKeycloakOrganization_realm(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm                 = "%s"
+	organizations_enabled = true
+}
+	`, realm)
+}
+
+func testKeycloakOrganization_basic(realm, name, domain string) string {
+	return testKeycloakOrganization_realm(realm) + fmt.Sprintf(`
+resource "keycloak_organization" "organization" {
+	realm_id    = keycloak_realm.realm.id
+	name        = "%s"
+	description = "An organization"
+
+	domain {
+		name     = "%s"
+		verified = true
+	}
+
+	attributes = {
+		tier = "gold"
+	}
+}
+	`, name, domain)
+}
@@ -180,6 +180,11 @@ func resourceKeycloakRealm() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"organizations_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
 			// Login Config
 			"registration_allowed": {
@@ -764,6 +769,11 @@ func getRealmFromData(data *schema.ResourceData) (*keycloak.Realm, error) {
 		DefaultLocale:               defaultLocale,
 	}
 
+	// organizations
+	if organizationsEnabled := data.Get("organizations_enabled").(bool); organizationsEnabled || data.HasChange("organizations_enabled") {
+		realm.OrganizationsEnabled = &organizationsEnabled
+	}
+
 	// smtp
 	if v, ok := data.GetOk("smtp_server"); ok {
 		smtpSettings := v.([]interface{})[0].(map[string]interface{})
@@ -1180,6 +1190,7 @@ func setRealmData(data *schema.ResourceData, realm *keycloak.Realm) {
 	data.Set("display_name", realm.DisplayName)
 	data.Set("display_name_html", realm.DisplayNameHtml)
 	data.Set("user_managed_access", realm.UserManagedAccess)
+	data.Set("organizations_enabled", realm.OrganizationsEnabled != nil && *realm.OrganizationsEnabled)
 
 	// Login Config
 	data.Set("registration_allowed", realm.RegistrationAllowed)
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmClientPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmClientPolicyCreate,
+		ReadContext:   resourceKeycloakRealmClientPolicyRead,
+		UpdateContext: resourceKeycloakRealmClientPolicyUpdate,
+		DeleteContext: resourceKeycloakRealmClientPolicyDelete,
+		// This resource can be imported using {{realm}}/{{name}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"condition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The provider ID of the condition, such as client-roles or client-updater-context",
+						},
+						"configuration": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "{}",
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+						},
+					},
+				},
+			},
+			"profiles": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "The names of the client policy profiles that are applied to the clients matching the conditions",
+			},
+		},
+	}
+}
+
+func getRealmClientPolicyFromData(data *schema.ResourceData) (*keycloak.RealmClientPolicy, error) {
+	conditions := make([]*keycloak.RealmClientPolicyCondition, 0)
+
+	for _, v := range data.Get("condition").([]interface{}) {
+		condition := v.(map[string]interface{})
+
+		configuration, err := getRealmClientPolicyConfigurationFromData(condition["configuration"].(string))
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, &keycloak.RealmClientPolicyCondition{
+			Condition:     condition["name"].(string),
+			Configuration: configuration,
+		})
+	}
+
+	return &keycloak.RealmClientPolicy{
+		RealmId:     data.Get("realm_id").(string),
+		Name:        data.Get("name").(string),
+		Description: data.Get("description").(string),
+		Enabled:     data.Get("enabled").(bool),
+		Conditions:  conditions,
+		Profiles:    interfaceSliceToStringSlice(data.Get("profiles").([]interface{})),
+	}, nil
+}
+
+func setRealmClientPolicyData(data *schema.ResourceData, policy *keycloak.RealmClientPolicy) error {
+	conditions := make([]interface{}, 0, len(policy.Conditions))
+
+	for _, condition := range policy.Conditions {
+		configuration, err := getRealmClientPolicyConfigurationData(condition.Configuration)
+		if err != nil {
+			return err
+		}
+
+		conditions = append(conditions, map[string]interface{}{
+			"name":          condition.Condition,
+			"configuration": configuration,
+		})
+	}
+
+	data.SetId(realmClientPolicyId(policy.RealmId, policy.Name))
+	data.Set("realm_id", policy.RealmId)
+	data.Set("name", policy.Name)
+	data.Set("description", policy.Description)
+	data.Set("enabled", policy.Enabled)
+	data.Set("condition", conditions)
+	data.Set("profiles", policy.Profiles)
+
+	return nil
+}
+
+func resourceKeycloakRealmClientPolicyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	policy, err := getRealmClientPolicyFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.NewRealmClientPolicy(ctx, policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(realmClientPolicyId(policy.RealmId, policy.Name))
+
+	return resourceKeycloakRealmClientPolicyRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPolicyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	policy, err := keycloakClient.GetRealmClientPolicy(ctx, data.Get("realm_id").(string), data.Get("name").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return diag.FromErr(setRealmClientPolicyData(data, policy))
+}
+
+func resourceKeycloakRealmClientPolicyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	policy, err := getRealmClientPolicyFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateRealmClientPolicy(ctx, policy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakRealmClientPolicyRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPolicyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	return diag.FromErr(keycloakClient.DeleteRealmClientPolicy(ctx, data.Get("realm_id").(string), data.Get("name").(string)))
+}
+
+func resourceKeycloakRealmClientPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{name}}.")
+	}
+
+	_, err := keycloakClient.GetRealmClientPolicy(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("name", parts[1])
+
+	diagnostics := resourceKeycloakRealmClientPolicyRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmClientPolicyProfile() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmClientPolicyProfileCreate,
+		ReadContext:   resourceKeycloakRealmClientPolicyProfileRead,
+		UpdateContext: resourceKeycloakRealmClientPolicyProfileUpdate,
+		DeleteContext: resourceKeycloakRealmClientPolicyProfileDelete,
+		// This resource can be imported using {{realm}}/{{name}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientPolicyProfileImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"executor": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The provider ID of the executor, such as pkce-enforcer or secure-client-authenticator",
+						},
+						"configuration": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "{}",
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func realmClientPolicyId(realmId, name string) string {
+	return fmt.Sprintf("%s/%s", realmId, name)
+}
+
+func getRealmClientPolicyConfigurationFromData(configuration string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+
+	err := json.Unmarshal([]byte(configuration), &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %v", err)
+	}
+
+	return result, nil
+}
+
+func getRealmClientPolicyConfigurationData(configuration map[string]interface{}) (string, error) {
+	if configuration == nil {
+		configuration = make(map[string]interface{})
+	}
+
+	result, err := json.Marshal(configuration)
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+func getRealmClientPolicyProfileFromData(data *schema.ResourceData) (*keycloak.RealmClientPolicyProfile, error) {
+	executors := make([]*keycloak.RealmClientPolicyProfileExecutor, 0)
+
+	for _, v := range data.Get("executor").([]interface{}) {
+		executor := v.(map[string]interface{})
+
+		configuration, err := getRealmClientPolicyConfigurationFromData(executor["configuration"].(string))
+		if err != nil {
+			return nil, err
+		}
+
+		executors = append(executors, &keycloak.RealmClientPolicyProfileExecutor{
+			Executor:      executor["name"].(string),
+			Configuration: configuration,
+		})
+	}
+
+	return &keycloak.RealmClientPolicyProfile{
+		RealmId:     data.Get("realm_id").(string),
+		Name:        data.Get("name").(string),
+		Description: data.Get("description").(string),
+		Executors:   executors,
+	}, nil
+}
+
+func setRealmClientPolicyProfileData(data *schema.ResourceData, profile *keycloak.RealmClientPolicyProfile) error {
+	executors := make([]interface{}, 0, len(profile.Executors))
+
+	for _, executor := range profile.Executors {
+		configuration, err := getRealmClientPolicyConfigurationData(executor.Configuration)
+		if err != nil {
+			return err
+		}
+
+		executors = append(executors, map[string]interface{}{
+			"name":          executor.Executor,
+			"configuration": configuration,
+		})
+	}
+
+	data.SetId(realmClientPolicyId(profile.RealmId, profile.Name))
+	data.Set("realm_id", profile.RealmId)
+	data.Set("name", profile.Name)
+	data.Set("description", profile.Description)
+	data.Set("executor", executors)
+
+	return nil
+}
+
+func resourceKeycloakRealmClientPolicyProfileCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	profile, err := getRealmClientPolicyProfileFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.NewRealmClientPolicyProfile(ctx, profile)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(realmClientPolicyId(profile.RealmId, profile.Name))
+
+	return resourceKeycloakRealmClientPolicyProfileRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPolicyProfileRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	profile, err := keycloakClient.GetRealmClientPolicyProfile(ctx, data.Get("realm_id").(string), data.Get("name").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return diag.FromErr(setRealmClientPolicyProfileData(data, profile))
+}
+
+func resourceKeycloakRealmClientPolicyProfileUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	profile, err := getRealmClientPolicyProfileFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateRealmClientPolicyProfile(ctx, profile)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakRealmClientPolicyProfileRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPolicyProfileDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	return diag.FromErr(keycloakClient.DeleteRealmClientPolicyProfile(ctx, data.Get("realm_id").(string), data.Get("name").(string)))
+}
+
+func resourceKeycloakRealmClientPolicyProfileImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{name}}.")
+	}
+
+	_, err := keycloakClient.GetRealmClientPolicyProfile(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", parts[0])
+	d.Set("name", parts[1])
+
+	diagnostics := resourceKeycloakRealmClientPolicyProfileRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmClientPolicyProfile_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientPolicyProfileDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientPolicyProfile_basic(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientPolicyProfileExists("keycloak_realm_client_policy_profile.profile"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy_profile.profile", "executor.#", "2"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy_profile.profile", "executor.0.name", "pkce-enforcer"),
+				),
+			},
+			{
+				Config: testKeycloakRealmClientPolicyProfile_basic(name, false),
+				Check: func(s *terraform.State) error {
+					profile, err := getRealmClientPolicyProfileFromState(s, "keycloak_realm_client_policy_profile.profile")
+					if err != nil {
+						return err
+					}
+
+					if autoConfigure := profile.Executors[0].Configuration["auto-configure"]; autoConfigure != false {
+						return fmt.Errorf("expected auto-configure of the pkce-enforcer executor to be false, but was %v", autoConfigure)
+					}
+
+					return nil
+				},
+			},
+			{
+				ResourceName:      "keycloak_realm_client_policy_profile.profile",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm + "/" + name,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmClientPolicyProfile_unknownExecutor(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientPolicyProfileDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmClientPolicyProfile_executor(name, "does-not-exist"),
+				ExpectError: regexp.MustCompile(`client policy executor "does-not-exist" does not exist on the server`),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmClientPolicyProfileExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getRealmClientPolicyProfileFromState(s, resourceName)
+
+		return err
+	}
+}
+
+func testAccCheckKeycloakRealmClientPolicyProfileDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_client_policy_profile" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm_id"]
+			name := rs.Primary.Attributes["name"]
+
+			_, err := keycloakClient.GetRealmClientPolicyProfile(testCtx, realm, name)
+			if err == nil {
+				return fmt.Errorf("client policy profile %s still exists", name)
+			}
+			if !keycloak.ErrorIs404(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func getRealmClientPolicyProfileFromState(s *terraform.State, resourceName string) (*keycloak.RealmClientPolicyProfile, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	realm := rs.Primary.Attributes["realm_id"]
+	name := rs.Primary.Attributes["name"]
+
+	profile, err := keycloakClient.GetRealmClientPolicyProfile(testCtx, realm, name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client policy profile %s: %s", name, err)
+	}
+
+	return profile, nil
+}
+
+func testKeycloakRealmClientPolicyProfile_basic(name string, autoConfigure bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_policy_profile" "profile" {
+	realm_id    = data.keycloak_realm.realm.id
+	name        = "%s"
+	description = "PKCE and confidential clients"
+
+	executor {
+		name          = "pkce-enforcer"
+		configuration = jsonencode({
+			"auto-configure" = %t
+		})
+	}
+
+	executor {
+		name = "confidential-client"
+	}
+}
+	`, testAccRealm.Realm, name, autoConfigure)
+}
+
+func testKeycloakRealmClientPolicyProfile_executor(name, executor string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_policy_profile" "profile" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+
+	executor {
+		name = "%s"
+	}
+}
+	`, testAccRealm.Realm, name, executor)
+}
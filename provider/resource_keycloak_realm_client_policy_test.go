@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmClientPolicy_basic(t *testing.T) {
+	profileName := acctest.RandomWithPrefix("tf-acc")
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientPolicyDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientPolicy_basic(profileName, policyName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientPolicyExists("keycloak_realm_client_policy.policy"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy.policy", "enabled", "true"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy.policy", "condition.#", "1"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policy.policy", "profiles.0", profileName),
+				),
+			},
+			{
+				Config: testKeycloakRealmClientPolicy_basic(profileName, policyName, false),
+				Check: func(s *terraform.State) error {
+					policy, err := getRealmClientPolicyFromState(s, "keycloak_realm_client_policy.policy")
+					if err != nil {
+						return err
+					}
+
+					if policy.Enabled {
+						return fmt.Errorf("expected client policy %s to be disabled", policy.Name)
+					}
+
+					return nil
+				},
+			},
+			{
+				ResourceName:      "keycloak_realm_client_policy.policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm + "/" + policyName,
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmClientPolicy_unknownCondition(t *testing.T) {
+	policyName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientPolicyDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakRealmClientPolicy_condition(policyName, "does-not-exist"),
+				ExpectError: regexp.MustCompile(`client policy condition "does-not-exist" does not exist on the server`),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmClientPolicyExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getRealmClientPolicyFromState(s, resourceName)
+
+		return err
+	}
+}
+
+func testAccCheckKeycloakRealmClientPolicyDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_client_policy" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm_id"]
+			name := rs.Primary.Attributes["name"]
+
+			_, err := keycloakClient.GetRealmClientPolicy(testCtx, realm, name)
+			if err == nil {
+				return fmt.Errorf("client policy %s still exists", name)
+			}
+			if !keycloak.ErrorIs404(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func getRealmClientPolicyFromState(s *terraform.State, resourceName string) (*keycloak.RealmClientPolicy, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	realm := rs.Primary.Attributes["realm_id"]
+	name := rs.Primary.Attributes["name"]
+
+	policy, err := keycloakClient.GetRealmClientPolicy(testCtx, realm, name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client policy %s: %s", name, err)
+	}
+
+	return policy, nil
+}
+
+func testKeycloakRealmClientPolicy_basic(profileName, policyName string, enabled bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_policy_profile" "profile" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+
+	executor {
+		name          = "pkce-enforcer"
+		configuration = jsonencode({
+			"auto-configure" = true
+		})
+	}
+}
+
+resource "keycloak_realm_client_policy" "policy" {
+	realm_id    = data.keycloak_realm.realm.id
+	name        = "%s"
+	description = "Enforce PKCE for public clients"
+	enabled     = %t
+
+	condition {
+		name          = "client-access-type"
+		configuration = jsonencode({
+			type = ["public"]
+		})
+	}
+
+	profiles = [
+		keycloak_realm_client_policy_profile.profile.name,
+	]
+}
+	`, testAccRealm.Realm, profileName, policyName, enabled)
+}
+
+func testKeycloakRealmClientPolicy_condition(policyName, condition string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_policy" "policy" {
+	realm_id = data.keycloak_realm.realm.id
+	name     = "%s"
+
+	condition {
+		name = "%s"
+	}
+}
+	`, testAccRealm.Realm, policyName, condition)
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmLocalization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmLocalizationReconcile,
+		ReadContext:   resourceKeycloakRealmLocalizationRead,
+		UpdateContext: resourceKeycloakRealmLocalizationReconcile,
+		DeleteContext: resourceKeycloakRealmLocalizationDelete,
+		// This resource can be imported using {{realm}}/{{locale}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmLocalizationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"locale": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"texts": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"exhaustive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func realmLocalizationId(realmId, locale string) string {
+	return fmt.Sprintf("%s/%s", realmId, locale)
+}
+
+func getRealmLocalizationTextsFromData(texts interface{}) map[string]string {
+	localizationTexts := make(map[string]string)
+
+	for key, text := range texts.(map[string]interface{}) {
+		localizationTexts[key] = text.(string)
+	}
+
+	return localizationTexts
+}
+
+func resourceKeycloakRealmLocalizationReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+	exhaustive := data.Get("exhaustive").(bool)
+
+	oldTexts, newTexts := data.GetChange("texts")
+	texts := getRealmLocalizationTextsFromData(newTexts)
+
+	existingTexts, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	textsToUpdate := make(map[string]string)
+	for key, text := range texts {
+		if existingText, ok := existingTexts[key]; !ok || existingText != text {
+			textsToUpdate[key] = text
+		}
+	}
+
+	err = keycloakClient.UpdateRealmLocalizationTexts(ctx, realmId, locale, textsToUpdate)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// when exhaustive, every text that isn't configured is removed. otherwise, only the texts that were removed from the configuration are.
+	textsToDelete := make([]string, 0)
+	if exhaustive {
+		for key := range existingTexts {
+			if _, ok := texts[key]; !ok {
+				textsToDelete = append(textsToDelete, key)
+			}
+		}
+	} else {
+		for key := range oldTexts.(map[string]interface{}) {
+			_, configured := texts[key]
+			_, exists := existingTexts[key]
+			if !configured && exists {
+				textsToDelete = append(textsToDelete, key)
+			}
+		}
+	}
+
+	for _, key := range textsToDelete {
+		err = keycloakClient.DeleteRealmLocalizationText(ctx, realmId, locale, key)
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId(realmLocalizationId(realmId, locale))
+
+	return resourceKeycloakRealmLocalizationRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmLocalizationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+	exhaustive := data.Get("exhaustive").(bool)
+
+	existingTexts, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale, false)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	// when not exhaustive, texts managed elsewhere are ignored
+	texts := make(map[string]string)
+	managedTexts := getRealmLocalizationTextsFromData(data.Get("texts"))
+	for key, text := range existingTexts {
+		if _, ok := managedTexts[key]; exhaustive || ok {
+			texts[key] = text
+		}
+	}
+
+	data.Set("texts", texts)
+
+	return nil
+}
+
+func resourceKeycloakRealmLocalizationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+
+	if data.Get("exhaustive").(bool) {
+		err := keycloakClient.DeleteRealmLocalizationTexts(ctx, realmId, locale)
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	for key := range getRealmLocalizationTextsFromData(data.Get("texts")) {
+		err := keycloakClient.DeleteRealmLocalizationText(ctx, realmId, locale, key)
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmLocalizationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{locale}}.")
+	}
+
+	realmId := parts[0]
+	locale := parts[1]
+
+	if _, err := keycloakClient.GetRealm(ctx, realmId); err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.Set("locale", locale)
+	d.Set("exhaustive", true)
+
+	diagnostics := resourceKeycloakRealmLocalizationRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
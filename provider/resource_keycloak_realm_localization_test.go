@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakRealmLocalization_basic(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_texts(realmName, true, map[string]string{
+					"loginTitle":  "Sign in to Example",
+					"emailVerify": "Please verify your email",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_localization.localization", "texts.%", "2"),
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "loginTitle", "Sign in to Example"),
+				),
+			},
+			{
+				Config: testKeycloakRealmLocalization_texts(realmName, true, map[string]string{
+					"loginTitle": "Welcome to Example",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_localization.localization", "texts.%", "1"),
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "loginTitle", "Welcome to Example"),
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "emailVerify", ""),
+				),
+			},
+			{
+				ResourceName:      "keycloak_realm_localization.localization",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     realmName + "/en",
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmLocalization_exhaustiveRemovesUnmanagedTexts(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_texts(realmName, true, map[string]string{
+					"loginTitle": "Sign in to Example",
+				}),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.UpdateRealmLocalizationTexts(testCtx, realmName, "en", map[string]string{
+						"emailVerify": "Added manually",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmLocalization_texts(realmName, true, map[string]string{
+					"loginTitle": "Sign in to Example",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_localization.localization", "texts.%", "1"),
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "emailVerify", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmLocalization_nonExhaustive(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_texts(realmName, false, map[string]string{
+					"loginTitle":  "Sign in to Example",
+					"emailVerify": "Please verify your email",
+				}),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.UpdateRealmLocalizationTexts(testCtx, realmName, "en", map[string]string{
+						"doLogIn": "Added manually",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmLocalization_texts(realmName, false, map[string]string{
+					"loginTitle": "Sign in to Example",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_localization.localization", "texts.%", "1"),
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "emailVerify", ""),
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "doLogIn", "Added manually"),
+				),
+			},
+			{
+				// destroying a non exhaustive localization only removes the texts it manages
+				Config: testKeycloakRealmLocalization_realmOnly(realmName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "loginTitle", ""),
+					testAccCheckKeycloakRealmLocalizationText(realmName, "en", "doLogIn", "Added manually"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmLocalizationText(realmName, locale, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		texts, err := keycloakClient.GetRealmLocalizationTexts(testCtx, realmName, locale, false)
+		if err != nil {
+			return err
+		}
+
+		if texts[key] != expected {
+			return fmt.Errorf("expected text %s of locale %s to be %q, but was %q", key, locale, expected, texts[key])
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmLocalization_texts(realm string, exhaustive bool, texts map[string]string) string {
+	textsHcl := ""
+	for key, text := range texts {
+		textsHcl += fmt.Sprintf("    %s = %q\n", key, text)
+	}
+
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+	internationalization {
+		supported_locales = ["en", "de"]
+		default_locale    = "en"
+	}
+}
+
+resource "keycloak_realm_localization" "localization" {
+	realm_id   = keycloak_realm.realm.id
+	locale     = "en"
+	exhaustive = %t
+
+	texts = {
+%s	}
+}
+	`, realm, exhaustive, textsHcl)
+}
+
+func testKeycloakRealmLocalization_realmOnly(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+	internationalization {
+		supported_locales = ["en", "de"]
+		default_locale    = "en"
+	}
+}
+	`, realm)
+}
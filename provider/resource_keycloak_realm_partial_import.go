@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmPartialImport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmPartialImportCreate,
+		ReadContext:   resourceKeycloakRealmPartialImportRead,
+		DeleteContext: resourceKeycloakRealmPartialImportDelete,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"realm_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description:      "A realm representation, such as a realm export. Its users, groups, clients, identity providers and roles are imported.",
+			},
+			"if_resource_exists": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "FAIL",
+				ValidateFunc: validation.StringInSlice(keycloak.PartialImportIfResourceExistsPolicies, false),
+			},
+			"imported_resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func mapFromPartialImportResultsToData(data *schema.ResourceData, results []*keycloak.PartialImportResult) {
+	var importedResources []interface{}
+
+	for _, result := range results {
+		importedResources = append(importedResources, map[string]interface{}{
+			"resource_type": result.ResourceType,
+			"resource_name": result.ResourceName,
+			"id":            result.Id,
+			"action":        result.Action,
+		})
+	}
+
+	data.Set("imported_resources", importedResources)
+}
+
+func mapFromDataToPartialImportResults(data *schema.ResourceData) []*keycloak.PartialImportResult {
+	var results []*keycloak.PartialImportResult
+
+	for _, importedResource := range data.Get("imported_resources").([]interface{}) {
+		importedResourceMap := importedResource.(map[string]interface{})
+
+		results = append(results, &keycloak.PartialImportResult{
+			ResourceType: importedResourceMap["resource_type"].(string),
+			ResourceName: importedResourceMap["resource_name"].(string),
+			Id:           importedResourceMap["id"].(string),
+			Action:       importedResourceMap["action"].(string),
+		})
+	}
+
+	return results
+}
+
+func resourceKeycloakRealmPartialImportCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	partialImport := &keycloak.PartialImport{
+		RealmId:          data.Get("realm_id").(string),
+		IfResourceExists: data.Get("if_resource_exists").(string),
+		RealmJson:        data.Get("realm_json").(string),
+	}
+
+	results, err := keycloakClient.NewPartialImport(ctx, partialImport)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(id.UniqueId())
+	mapFromPartialImportResultsToData(data, results.Results)
+
+	return resourceKeycloakRealmPartialImportRead(ctx, data, meta)
+}
+
+// The imported resources are managed outside of this resource after the import, so only the realm itself is checked
+func resourceKeycloakRealmPartialImportRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	_, err := keycloakClient.GetRealm(ctx, data.Get("realm_id").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmPartialImportDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	return diag.FromErr(keycloakClient.DeletePartialImportResults(ctx, realmId, mapFromDataToPartialImportResults(data)))
+}
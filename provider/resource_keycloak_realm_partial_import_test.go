@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakRealmPartialImport_basic(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+	roleName := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmPartialImport_basic(realmName, username, groupName, roleName, clientId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "imported_resources.#", "4"),
+					testAccCheckKeycloakRealmPartialImportUserExists(realmName, username, true),
+				),
+			},
+			{
+				// removing the import deletes what it added, but leaves the realm alone
+				Config: testKeycloakRealmPartialImport_realmOnly(realmName),
+				Check:  testAccCheckKeycloakRealmPartialImportUserExists(realmName, username, false),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmPartialImport_skipExisting(t *testing.T) {
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	username := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+	roleName := acctest.RandomWithPrefix("tf-acc")
+	clientId := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmPartialImport_existingGroup(realmName, username, groupName, roleName, clientId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("keycloak_realm_partial_import.import", "imported_resources.*", map[string]string{
+						"resource_type": "GROUP",
+						"resource_name": groupName,
+						"action":        "SKIPPED",
+					}),
+				),
+			},
+			{
+				// the skipped group existed before the import, so it must not be deleted with it
+				Config: testKeycloakRealmPartialImport_existingGroupOnly(realmName, groupName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakGroupExists("keycloak_group.group"),
+					testAccCheckKeycloakRealmPartialImportUserExists(realmName, username, false),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmPartialImportUserExists(realmName, username string, shouldExist bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		user, err := keycloakClient.GetUserByUsername(testCtx, realmName, username)
+		if err != nil {
+			return err
+		}
+
+		if shouldExist && user == nil {
+			return fmt.Errorf("expected imported user %s to exist", username)
+		}
+
+		if !shouldExist && user != nil {
+			return fmt.Errorf("expected imported user %s to be deleted", username)
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmPartialImport_realmJson(username, groupName, roleName, clientId string) string {
+	return fmt.Sprintf(`jsonencode({
+		realm = "ignored"
+		users = [
+			{
+				username = "%s"
+				enabled  = true
+			}
+		]
+		groups = [
+			{
+				name = "%s"
+			}
+		]
+		roles = {
+			realm = [
+				{
+					name = "%s"
+				}
+			]
+		}
+		clients = [
+			{
+				clientId     = "%s"
+				protocol     = "openid-connect"
+				publicClient = true
+			}
+		]
+	})`, username, groupName, roleName, clientId)
+}
+
+func testKeycloakRealmPartialImport_realmOnly(realmName string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+	`, realmName)
+}
+
+func testKeycloakRealmPartialImport_basic(realmName, username, groupName, roleName, clientId string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_partial_import" "import" {
+	realm_id   = keycloak_realm.realm.id
+	realm_json = %s
+}
+	`, realmName, testKeycloakRealmPartialImport_realmJson(username, groupName, roleName, clientId))
+}
+
+func testKeycloakRealmPartialImport_existingGroupOnly(realmName, groupName string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_group" "group" {
+	realm_id = keycloak_realm.realm.id
+	name     = "%s"
+}
+	`, realmName, groupName)
+}
+
+func testKeycloakRealmPartialImport_existingGroup(realmName, username, groupName, roleName, clientId string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "keycloak_realm_partial_import" "import" {
+	realm_id           = keycloak_realm.realm.id
+	if_resource_exists = "SKIP"
+	realm_json         = %s
+
+	depends_on = [
+		keycloak_group.group
+	]
+}
+	`, testKeycloakRealmPartialImport_existingGroupOnly(realmName, groupName), testKeycloakRealmPartialImport_realmJson(username, groupName, roleName, clientId))
+}
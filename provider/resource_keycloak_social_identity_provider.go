@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/imdario/mergo"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/types"
+)
+
+// social identity provider attributes which are only supported by specific providers, keyed by the provider IDs that support them
+var keycloakSocialIdentityProviderSpecificAttributes = map[string][]string{
+	"hosted_domain":         {"google"},
+	"use_user_ip_param":     {"google"},
+	"request_refresh_token": {"google"},
+	"tenant_id":             {"microsoft"},
+	"base_url":              {"github"},
+	"api_url":               {"github"},
+	"fetched_fields":        {"facebook"},
+}
+
+func resourceKeycloakSocialIdentityProvider() *schema.Resource {
+	socialSchema := map[string]*schema.Schema{
+		"alias": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "The alias uniquely identifies an identity provider and it is also used to build the redirect uri. Defaults to the provider id.",
+		},
+		"provider_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The id of the social provider, such as github, microsoft, gitlab, facebook, linkedin-openid-connect or bitbucket. It must be installed on the server.",
+		},
+		"client_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Client ID.",
+		},
+		"client_secret": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Client Secret.",
+		},
+		"default_scopes": { // defaultScope
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The scopes to be sent when asking for authorization. When empty, the default scopes of the provider are used.",
+		},
+		"accepts_prompt_none_forward_from_client": { // acceptsPromptNoneForwardFromClient
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "This is just used together with Identity Provider Authenticator or when kc_idp_hint points to this identity provider. In case that client sends a request with prompt=none and user is not yet authenticated, the error will not be directly returned to client, but the request with prompt=none will be forwarded to this identity provider.",
+		},
+		"hide_on_login_page": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Hide On Login Page.",
+		},
+		"hosted_domain": { // hostedDomain
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Google only. Set 'hd' query parameter when logging in with Google. Google will list accounts only for this domain. When '*' is entered, any hosted account can be used.",
+		},
+		"use_user_ip_param": { // userIp
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Google only. Set 'userIp' query parameter when invoking on Google's User Info service. This will use the user's ip address.",
+		},
+		"request_refresh_token": { // offlineAccess
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Google only. Set 'access_type' query parameter to 'offline' when redirecting to google authorization endpoint, to get a refresh token back.",
+		},
+		"tenant_id": { // tenantId
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Microsoft only. The tenant id, used to restrict logins to a single Microsoft Entra ID tenant. When empty, users of any tenant can log in.",
+		},
+		"base_url": { // baseUrl
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "GitHub only. Override the default base URL of GitHub, which is useful for GitHub Enterprise.",
+		},
+		"api_url": { // apiUrl
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "GitHub only. Override the default API URL of GitHub, which is useful for GitHub Enterprise.",
+		},
+		"fetched_fields": { // fetchedFields
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Facebook only. A comma separated list of additional profile fields to fetch from the Facebook Graph API.",
+		},
+	}
+	socialResource := resourceKeycloakIdentityProvider()
+	socialResource.Schema = mergeSchemas(socialResource.Schema, socialSchema)
+	socialResource.CreateContext = resourceKeycloakSocialIdentityProviderCreate
+	socialResource.ReadContext = resourceKeycloakIdentityProviderRead(setSocialIdentityProviderData)
+	socialResource.UpdateContext = resourceKeycloakSocialIdentityProviderUpdate
+	return socialResource
+}
+
+func getSocialIdentityProviderFromData(data *schema.ResourceData) (*keycloak.IdentityProvider, error) {
+	rec, defaultConfig := getIdentityProviderFromData(data)
+	rec.ProviderId = data.Get("provider_id").(string)
+	if rec.Alias == "" {
+		rec.Alias = rec.ProviderId
+	}
+
+	for attribute, providerIds := range keycloakSocialIdentityProviderSpecificAttributes {
+		if _, ok := data.GetOk(attribute); ok && !stringSliceContains(providerIds, rec.ProviderId) {
+			return nil, fmt.Errorf("%s is only supported by the %s identity provider", attribute, strings.Join(providerIds, ", "))
+		}
+	}
+
+	// settings which are only used by specific providers are sent as extra config, and omitted when they aren't set so the provider defaults apply
+	for attribute, configKey := range map[string]string{
+		"tenant_id":      "tenantId",
+		"base_url":       "baseUrl",
+		"api_url":        "apiUrl",
+		"fetched_fields": "fetchedFields",
+	} {
+		if v, ok := data.GetOk(attribute); ok {
+			defaultConfig.ExtraConfig[configKey] = v.(string)
+		}
+	}
+
+	socialIdentityProviderConfig := &keycloak.IdentityProviderConfig{
+		ClientId:                    data.Get("client_id").(string),
+		ClientSecret:                data.Get("client_secret").(string),
+		DefaultScope:                data.Get("default_scopes").(string),
+		AcceptsPromptNoneForwFrmClt: types.KeycloakBoolQuoted(data.Get("accepts_prompt_none_forward_from_client").(bool)),
+		HideOnLoginPage:             types.KeycloakBoolQuoted(data.Get("hide_on_login_page").(bool)),
+		HostedDomain:                data.Get("hosted_domain").(string),
+		UserIp:                      types.KeycloakBoolQuoted(data.Get("use_user_ip_param").(bool)),
+		OfflineAccess:               types.KeycloakBoolQuoted(data.Get("request_refresh_token").(bool)),
+	}
+
+	if err := mergo.Merge(socialIdentityProviderConfig, defaultConfig); err != nil {
+		return nil, err
+	}
+
+	rec.Config = socialIdentityProviderConfig
+
+	return rec, nil
+}
+
+func setSocialIdentityProviderData(data *schema.ResourceData, identityProvider *keycloak.IdentityProvider) error {
+	setIdentityProviderData(data, identityProvider)
+	data.Set("provider_id", identityProvider.ProviderId)
+	data.Set("client_id", identityProvider.Config.ClientId)
+	data.Set("default_scopes", identityProvider.Config.DefaultScope)
+	data.Set("accepts_prompt_none_forward_from_client", identityProvider.Config.AcceptsPromptNoneForwFrmClt)
+	data.Set("hide_on_login_page", identityProvider.Config.HideOnLoginPage)
+	data.Set("hosted_domain", identityProvider.Config.HostedDomain)
+	data.Set("use_user_ip_param", identityProvider.Config.UserIp)
+	data.Set("request_refresh_token", identityProvider.Config.OfflineAccess)
+	data.Set("tenant_id", getSocialIdentityProviderExtraConfigValue(identityProvider, "tenantId"))
+	data.Set("base_url", getSocialIdentityProviderExtraConfigValue(identityProvider, "baseUrl"))
+	data.Set("api_url", getSocialIdentityProviderExtraConfigValue(identityProvider, "apiUrl"))
+	data.Set("fetched_fields", getSocialIdentityProviderExtraConfigValue(identityProvider, "fetchedFields"))
+	return nil
+}
+
+func getSocialIdentityProviderExtraConfigValue(identityProvider *keycloak.IdentityProvider, key string) string {
+	if v, ok := identityProvider.Config.ExtraConfig[key].(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+func resourceKeycloakSocialIdentityProviderCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := validateSocialIdentityProvider(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceKeycloakIdentityProviderCreate(getSocialIdentityProviderFromData, setSocialIdentityProviderData)(ctx, data, meta)
+}
+
+func resourceKeycloakSocialIdentityProviderUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := validateSocialIdentityProvider(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceKeycloakIdentityProviderUpdate(getSocialIdentityProviderFromData, setSocialIdentityProviderData)(ctx, data, meta)
+}
+
+func validateSocialIdentityProvider(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	identityProvider, err := getSocialIdentityProviderFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(keycloakClient.ValidateSocialIdentityProvider(ctx, identityProvider))
+}
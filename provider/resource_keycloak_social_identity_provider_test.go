@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakSocialIdentityProvider_github(t *testing.T) {
+	t.Parallel()
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSocialIdentityProvider_github(alias, "https://github.example.com", "https://github.example.com/api/v3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasExtraConfigValue("keycloak_social_identity_provider.github", "baseUrl", "https://github.example.com"),
+					testAccCheckKeycloakSocialIdentityProviderHasExtraConfigValue("keycloak_social_identity_provider.github", "apiUrl", "https://github.example.com/api/v3"),
+					resource.TestCheckResourceAttr("keycloak_social_identity_provider.github", "provider_id", "github"),
+				),
+			},
+			{
+				Config: testKeycloakSocialIdentityProvider_github(alias, "https://github.example.org", "https://github.example.org/api/v3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasExtraConfigValue("keycloak_social_identity_provider.github", "baseUrl", "https://github.example.org"),
+					testAccCheckKeycloakSocialIdentityProviderHasExtraConfigValue("keycloak_social_identity_provider.github", "apiUrl", "https://github.example.org/api/v3"),
+				),
+			},
+			{
+				ResourceName:            "keycloak_social_identity_provider.github",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     testAccRealm.Realm + "/",
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSocialIdentityProvider_microsoft(t *testing.T) {
+	t.Parallel()
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+	tenantId := acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSocialIdentityProvider_microsoft(alias, tenantId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakSocialIdentityProviderHasExtraConfigValue("keycloak_social_identity_provider.microsoft", "tenantId", tenantId),
+					resource.TestCheckResourceAttr("keycloak_social_identity_provider.microsoft", "tenant_id", tenantId),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSocialIdentityProvider_createAfterManualDestroy(t *testing.T) {
+	t.Parallel()
+
+	var idp = &keycloak.IdentityProvider{}
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakSocialIdentityProvider_basic(alias, "gitlab"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderFetch("keycloak_social_identity_provider.social", idp),
+			},
+			{
+				PreConfig: func() {
+					err := keycloakClient.DeleteIdentityProvider(testCtx, idp.Realm, idp.Alias)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakSocialIdentityProvider_basic(alias, "gitlab"),
+				Check:  testAccCheckKeycloakSocialIdentityProviderExists("keycloak_social_identity_provider.social"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSocialIdentityProvider_invalidProviderId(t *testing.T) {
+	t.Parallel()
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakSocialIdentityProvider_basic(alias, acctest.RandomWithPrefix("tf-acc")),
+				ExpectError: regexp.MustCompile("validation error: social identity provider \".+\" does not exist on the server"),
+			},
+		},
+	})
+}
+
+func TestAccKeycloakSocialIdentityProvider_unsupportedAttribute(t *testing.T) {
+	t.Parallel()
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakSocialIdentityProviderDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakSocialIdentityProvider_unsupportedAttribute(alias),
+				ExpectError: regexp.MustCompile("tenant_id is only supported by the microsoft identity provider"),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakSocialIdentityProviderExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, err := getKeycloakSocialIdentityProviderFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakSocialIdentityProviderFetch(resourceName string, idp *keycloak.IdentityProvider) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedIdp, err := getKeycloakSocialIdentityProviderFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		idp.Alias = fetchedIdp.Alias
+		idp.Realm = fetchedIdp.Realm
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakSocialIdentityProviderHasExtraConfigValue(resourceName, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		fetchedIdp, err := getKeycloakSocialIdentityProviderFromState(s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		if fetchedIdp.Config.ExtraConfig[key] != value {
+			return fmt.Errorf("expected social identity provider to have config key '%s' with a value %s, but value was %v", key, value, fetchedIdp.Config.ExtraConfig[key])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakSocialIdentityProviderDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_social_identity_provider" {
+				continue
+			}
+
+			id := rs.Primary.ID
+			realm := rs.Primary.Attributes["realm"]
+
+			idp, _ := keycloakClient.GetIdentityProvider(testCtx, realm, id)
+			if idp != nil {
+				return fmt.Errorf("social identity provider with alias %s still exists", id)
+			}
+		}
+
+		return nil
+	}
+}
+
+func getKeycloakSocialIdentityProviderFromState(s *terraform.State, resourceName string) (*keycloak.IdentityProvider, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("resource not found: %s", resourceName)
+	}
+
+	realm := rs.Primary.Attributes["realm"]
+	alias := rs.Primary.Attributes["alias"]
+
+	idp, err := keycloakClient.GetIdentityProvider(testCtx, realm, alias)
+	if err != nil {
+		return nil, fmt.Errorf("error getting social identity provider with alias %s: %s", alias, err)
+	}
+
+	return idp, nil
+}
+
+func testKeycloakSocialIdentityProvider_basic(alias, providerId string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_social_identity_provider" "social" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "%s"
+	provider_id   = "%s"
+	client_id     = "example_id"
+	client_secret = "example_token"
+}
+	`, testAccRealm.Realm, alias, providerId)
+}
+
+func testKeycloakSocialIdentityProvider_github(alias, baseUrl, apiUrl string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_social_identity_provider" "github" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "%s"
+	provider_id   = "github"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	base_url      = "%s"
+	api_url       = "%s"
+}
+	`, testAccRealm.Realm, alias, baseUrl, apiUrl)
+}
+
+func testKeycloakSocialIdentityProvider_microsoft(alias, tenantId string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_social_identity_provider" "microsoft" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "%s"
+	provider_id   = "microsoft"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	tenant_id     = "%s"
+}
+	`, testAccRealm.Realm, alias, tenantId)
+}
+
+func testKeycloakSocialIdentityProvider_unsupportedAttribute(alias string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_social_identity_provider" "github" {
+	realm         = data.keycloak_realm.realm.id
+	alias         = "%s"
+	provider_id   = "github"
+	client_id     = "example_id"
+	client_secret = "example_token"
+
+	tenant_id     = "my-tenant"
+}
+	`, testAccRealm.Realm, alias)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
@@ -199,6 +200,20 @@ func mapFromUserToData(data *schema.ResourceData, user *keycloak.User) {
 	data.Set("required_actions", user.RequiredActions)
 }
 
+// userAttributePath maps the name of a user profile attribute to the attribute of this resource it is set from
+func userAttributePath(field string) cty.Path {
+	switch field {
+	case "username", "email":
+		return cty.GetAttrPath(field)
+	case "firstName":
+		return cty.GetAttrPath("first_name")
+	case "lastName":
+		return cty.GetAttrPath("last_name")
+	default:
+		return cty.GetAttrPath("attributes").IndexString(field)
+	}
+}
+
 func resourceKeycloakUserCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
@@ -206,7 +221,7 @@ func resourceKeycloakUserCreate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.NewUser(ctx, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, userAttributePath)
 	}
 
 	v, isInitialPasswordSet := data.GetOk("initial_password")
@@ -248,7 +263,7 @@ func resourceKeycloakUserUpdate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.UpdateUser(ctx, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, userAttributePath)
 	}
 
 	mapFromUserToData(data, user)
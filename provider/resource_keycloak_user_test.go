@@ -263,6 +263,25 @@ func TestAccKeycloakUser_validateLowercaseUsernames(t *testing.T) {
 	})
 }
 
+func TestAccKeycloakUser_invalidEmailPointsAtAttribute(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_23)
+
+	t.Parallel()
+	username := "terraform-user-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakUserDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testKeycloakUser_email(username, "not-an-email"),
+				ExpectError: regexp.MustCompile("Invalid value for email"),
+			},
+		},
+	})
+}
+
 func TestAccKeycloakUser_federatedLink(t *testing.T) {
 	sourceUserName := acctest.RandomWithPrefix("tf-acc")
 	sourceUserName2 := acctest.RandomWithPrefix("tf-acc")
@@ -423,6 +442,20 @@ resource "keycloak_user" "user" {
 	`, testAccRealm.Realm, username, attributeName, attributeValue)
 }
 
+func testKeycloakUser_email(username, email string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_user" "user" {
+	realm_id = data.keycloak_realm.realm.id
+	username = "%s"
+	email    = "%s"
+}
+	`, testAccRealm.Realm, username, email)
+}
+
 func testKeycloakUser_initialPassword(username string, password string, clientId string) string {
 	return fmt.Sprintf(`
 data "keycloak_realm" "realm" {
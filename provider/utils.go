@@ -2,6 +2,8 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"time"
@@ -79,6 +81,28 @@ func handleNotFoundError(ctx context.Context, err error, data *schema.ResourceDa
 	return diag.FromErr(err)
 }
 
+// apiErrorDiagnostics converts the field errors of a Keycloak API error into diagnostics. attributePath maps the name
+// of a field within the Keycloak representation to the attribute it was set from, so the diagnostic can point at it.
+// Errors without field errors are returned as a single diagnostic, the same way diag.FromErr would.
+func apiErrorDiagnostics(err error, attributePath func(field string) cty.Path) diag.Diagnostics {
+	apiError, ok := keycloak.AsApiError(err)
+	if !ok || len(apiError.FieldErrors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, fieldError := range apiError.FieldErrors {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value for %s", fieldError.Field),
+			Detail:        fmt.Sprintf("Keycloak rejected the value of %s: %s", fieldError.Field, fieldError.String()),
+			AttributePath: attributePath(fieldError.Field),
+		})
+	}
+
+	return diags
+}
+
 func interfaceSliceToStringSlice(iv []interface{}) []string {
 	var sv []string
 	for _, i := range iv {